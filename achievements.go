@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+// How long the win screen shows the unlocked achievements.
+var ACHIEVEMENT_TOAST_TIME = time.Second * 8
+
+type achievement struct {
+	id          string
+	name        string
+	description string
+	// Counted achievements unlock once the count reaches the goal.
+	goal int
+}
+
+var achievements = []achievement{
+	{"first-win", "First win", "Win a game", 0},
+	{"life-swap", "Life swap", "Swap the life card", 0},
+	{"deuce-over-ace", "Two beats one", "Win a trick with the 2 of the life suit against an ace", 0},
+	{"all-aces", "Ace collector", "Take all four aces in one game", 0},
+	{"shut-out", "Shut-out", "Win a game where the other side takes no points", 0},
+	{"partner-wins", "Ten with a friend", "Win 10 team games with the same partner", 10},
+}
+
+// achievementRecord is an account's unlocked achievements and the counts
+// toward the counted ones.
+type achievementRecord struct {
+	Unlocked map[string]time.Time `json:"unlocked"`
+	// Team games won with each partner.
+	PartnerWins map[string]int `json:"partnerWins"`
+}
+
+// progress is the count toward a counted achievement, the best partner's.
+func (r achievementRecord) progress(a achievement) (count int, partner string) {
+	if a.id != "partner-wins" {
+		return 0, ""
+	}
+	for p, wins := range r.PartnerWins {
+		if wins > count || wins == count && p < partner {
+			count, partner = wins, p
+		}
+	}
+	return count, partner
+}
+
+// achievementTrick is a finished trick, cards in play order from the leader.
+type achievementTrick struct {
+	leader int
+	cards  []card
+	winner int
+}
+
+// achievementGame is what the achievements look at in a finished game, seen
+// from the player's seat.
+type achievementGame struct {
+	players  int
+	mySeat   int
+	me       resultPlayer
+	partner  string // Only in 4 player games
+	lifeSuit string
+	tricks   []achievementTrick
+	swaps    []int // Seats, in order
+}
+
+// newAchievementGame walks the game's actions, ok is false for spectators.
+func (m gsModel) newAchievementGame(won gameWonPayload) (g achievementGame, ok bool) {
+	result := m.gameResult(won)
+	g.players = result.PlayerCount
+	g.mySeat = -1
+	for seat, p := range result.Players {
+		if p.Username == m.userGlobal.username {
+			g.mySeat, g.me = seat, p
+		}
+	}
+	if g.mySeat == -1 {
+		return g, false
+	}
+	if g.players == 4 {
+		g.partner = result.Players[(g.mySeat+2)%4].Username
+	}
+
+	var trick achievementTrick
+	for _, a := range m.actionCache.actions {
+		switch payload := a.Payload.(type) {
+		case gameStartedPayload:
+			trick.leader = payload.StartingSeat
+		case bottomCardSelectedPayload:
+			g.lifeSuit = payload.bottomCard.suitString
+		case swapBottomCardPayload:
+			g.swaps = append(g.swaps, (trick.leader+len(trick.cards))%g.players)
+		case cardPlayedPayload:
+			trick.cards = append(trick.cards, payload.card)
+		case turnWonPayload:
+			trick.winner = payload.Seat
+			g.tricks = append(g.tricks, trick)
+			trick = achievementTrick{leader: payload.Seat}
+		}
+	}
+	return g, true
+}
+
+func (g achievementGame) won() bool {
+	return g.me.Result == resultWin
+}
+
+func (g achievementGame) mine(seat int) bool {
+	return sideOf(seat, g.players) == sideOf(g.mySeat, g.players)
+}
+
+// earned is whether the game unlocks a one-off achievement.
+func (g achievementGame) earned(a achievement) bool {
+	switch a.id {
+	case "first-win":
+		return g.won()
+	case "life-swap":
+		for _, seat := range g.swaps {
+			if seat == g.mySeat {
+				return true
+			}
+		}
+	case "deuce-over-ace":
+		for _, t := range g.tricks {
+			if t.winner != g.mySeat {
+				continue
+			}
+			mine := t.cards[(g.mySeat-t.leader+g.players)%g.players]
+			if mine.num != 2 || mine.suitString != g.lifeSuit {
+				continue
+			}
+			for _, c := range t.cards {
+				if c.num == 1 {
+					return true
+				}
+			}
+		}
+	case "all-aces":
+		aces := 0
+		for _, t := range g.tricks {
+			if !g.mine(t.winner) {
+				continue
+			}
+			for _, c := range t.cards {
+				if c.num == 1 {
+					aces++
+				}
+			}
+		}
+		return aces == 4
+	case "shut-out":
+		if !g.won() {
+			return false
+		}
+		for _, t := range g.tricks {
+			if !g.mine(t.winner) && trickPoints(t.cards) > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// achievementFiles guards the achievement files, every session of an account
+// shares the same file.
+var achievementFiles sync.Mutex
+
+// achievementsPath is one JSON file per account next to its history.
+func achievementsPath(username string) string {
+	return filepath.Join(env.History, url.PathEscape(username)+".achievements.json")
+}
+
+func loadAchievements(username string) achievementRecord {
+	achievementFiles.Lock()
+	defer achievementFiles.Unlock()
+	return readAchievements(username)
+}
+
+func readAchievements(username string) achievementRecord {
+	record := achievementRecord{
+		Unlocked:    map[string]time.Time{},
+		PartnerWins: map[string]int{},
+	}
+	data, err := os.ReadFile(achievementsPath(username))
+	if errors.Is(err, fs.ErrNotExist) {
+		return record
+	}
+	if err != nil {
+		log.Error("Could not read the achievements", "error", err)
+		return record
+	}
+	err = json.Unmarshal(data, &record)
+	if err != nil {
+		log.Error("Could not parse the achievements", "error", err)
+	}
+	return record
+}
+
+func writeAchievements(username string, record achievementRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Error("Could not encode the achievements", "error", err)
+		return
+	}
+	err = os.MkdirAll(env.History, 0o755)
+	if err != nil {
+		log.Error("Could not create the history directory", "error", err)
+		return
+	}
+	err = os.WriteFile(achievementsPath(username), data, 0o644)
+	if err != nil {
+		log.Error("Could not write the achievements", "error", err)
+	}
+}
+
+// recordAchievements counts the game and returns what it unlocked.
+func recordAchievements(username string, g achievementGame) []achievement {
+	achievementFiles.Lock()
+	defer achievementFiles.Unlock()
+
+	record := readAchievements(username)
+	if g.partner != "" && g.won() {
+		record.PartnerWins[g.partner]++
+	}
+	var unlocked []achievement
+	for _, a := range achievements {
+		if _, ok := record.Unlocked[a.id]; ok {
+			continue
+		}
+		count, _ := record.progress(a)
+		if a.goal > 0 && count >= a.goal || a.goal == 0 && g.earned(a) {
+			record.Unlocked[a.id] = time.Now()
+			unlocked = append(unlocked, a)
+		}
+	}
+	writeAchievements(username, record)
+	return unlocked
+}
+
+type achievementsMsg []achievement
+
+type achievementToastsExpiredMsg struct{}
+
+// unlockAchievements counts a game the player played for real, replays, the
+// tutorial and the daily deal don't count.
+func (m gsModel) unlockAchievements(won gameWonPayload) tea.Cmd {
+	if m.replay || m.tutorial != nil || m.daily != nil {
+		return nil
+	}
+	g, ok := m.newAchievementGame(won)
+	if !ok {
+		return nil
+	}
+	username := m.userGlobal.username
+	return func() tea.Msg {
+		unlocked := recordAchievements(username, g)
+		if len(unlocked) == 0 {
+			return nil
+		}
+		return achievementsMsg(unlocked)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	unlockedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#25A065"))
+	lockedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+	achievementToastStyle = lipgloss.NewStyle().
+				BorderStyle(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#25A065")).
+				Padding(0, 1)
+)
+
+type achievementRecordMsg achievementRecord
+
+// achievementsModel lists every achievement, unlocked or with the progress
+// toward it.
+type achievementsModel struct {
+	record     achievementRecord
+	loaded     bool
+	back       key.Binding
+	userGlobal userGlobal
+}
+
+func newAchievements(userGlobal userGlobal) achievementsModel {
+	return achievementsModel{
+		back:       userGlobal.keymap.binding("achievements.back"),
+		userGlobal: userGlobal,
+	}
+}
+
+func (m achievementsModel) Init() tea.Cmd {
+	username := m.userGlobal.username
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), func() tea.Msg {
+		return achievementRecordMsg(loadAchievements(username))
+	})
+}
+
+func (m achievementsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+	case achievementRecordMsg:
+		m.record = achievementRecord(msg)
+		m.loaded = true
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		case key.Matches(msg, m.back):
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		}
+	}
+	return m, nil
+}
+
+func (m achievementsModel) View() string {
+	if !m.loaded {
+		return docStyle.Render(titleStyle.Render("Achievements") + "\n\nLoading...")
+	}
+	lines := []string{
+		titleStyle.Render("Achievements"),
+		fmt.Sprintf("%d of %d unlocked", len(m.record.Unlocked), len(achievements)),
+		"",
+	}
+	for _, a := range achievements {
+		unlocked, ok := m.record.Unlocked[a.id]
+		status := ""
+		switch count, partner := m.record.progress(a); {
+		case ok:
+			status = "unlocked " + unlocked.Local().Format("2006-01-02")
+		case count > 0:
+			status = fmt.Sprintf("%d/%d with %s", count, a.goal, partner)
+		}
+		name := lockedStyle.Render(a.name)
+		if ok {
+			name = unlockedStyle.Render(a.name)
+		}
+		lines = append(lines, profileLabelStyle.Width(20).Render(name)+a.description)
+		if status != "" {
+			lines = append(lines, profileLabelStyle.Width(20).Render("")+lockedStyle.Render(status))
+		}
+	}
+	lines = append(lines, "", helpStyle.Render(m.back.Help().Key+" "+m.back.Help().Desc))
+	return docStyle.Render(strings.Join(lines, "\n"))
+}
+
+// achievementToasts are the achievements a game unlocked, on the win screen.
+func achievementToasts(unlocked []achievement) string {
+	var toasts []string
+	for _, a := range unlocked {
+		toasts = append(toasts, achievementToastStyle.Render(
+			unlockedStyle.Render("Achievement unlocked: "+a.name)+"\n"+a.description))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, toasts...)
+}
@@ -25,13 +25,19 @@ const (
 // 	GAME_WON,
 // 	SEAT_AFK,
 // 	SEAT_NOT_AFK,
+// 	CHAT,
+// 	REACTION,
 // }
 
 type gameConfigPayload struct {
-	GameId         string `json:"gameId"`
-	GameType       string `json:"gameType"`
-	MaxPlayers     int    `json:"maxPlayers"`
-	SwapBottomCard bool   `json:"swapBottomCard"`
+	GameId         string       `json:"gameId"`
+	GameType       string       `json:"gameType"`
+	MaxPlayers     int          `json:"maxPlayers"`
+	SwapBottomCard bool         `json:"swapBottomCard"`
+	SwapRules      []swapRule   `json:"swapRules"`
+	Variant        string       `json:"variant"`
+	Match          *matchConfig `json:"match"`
+	Tournament     string       `json:"tournament"`
 }
 
 type gameStartedPayload struct {
@@ -46,7 +52,10 @@ type seat struct {
 
 type gracePeriodEndedPayload struct{}
 
-type swapBottomCardPayload struct{}
+type swapBottomCardPayload struct {
+	// The card that took the life card's place, older servers leave it out.
+	Card string `json:"card"`
+}
 
 type bottomCardSelectedPayload struct {
 	BottomCard string `json:"bottomCard"`
@@ -81,6 +90,15 @@ type seatNotAfkPayload struct {
 	Seat int `json:"seat"`
 }
 
+type chatPayload struct {
+	chatMessage
+}
+
+type reactionPayload struct {
+	Seat     int    `json:"seat"`
+	Reaction string `json:"reaction"`
+}
+
 // Client side action payloads
 // ============================================================================
 type turnSwitchPayload struct{}
@@ -124,6 +142,10 @@ func (a action) processAction(myTurn, gameOver bool, mySeat int) tea.Cmd {
 			}
 		case turnWonPayload:
 		case gameWonPayload:
+		case chatPayload:
+			slow = 0
+		case reactionPayload:
+			slow = 0
 
 		// Client side actions
 		case turnSwitchPayload:
@@ -216,7 +238,12 @@ func (a *action) UnmarshalJSON(b []byte) error {
 	case "GRACE_PERIOD_ENDED":
 		a.Payload = gracePeriodEndedPayload{}
 	case "SWAP_BOTTOM_CARD":
-		a.Payload = swapBottomCardPayload{}
+		swapBottomCard := swapBottomCardPayload{}
+		err := json.Unmarshal(payloadBytes, &swapBottomCard)
+		if err != nil {
+			return err
+		}
+		a.Payload = swapBottomCard
 	case "CARD_DRAWN":
 		cardDrawn := cardDrawnPayload{}
 		err := json.Unmarshal(payloadBytes, &cardDrawn)
@@ -250,6 +277,20 @@ func (a *action) UnmarshalJSON(b []byte) error {
 		a.Payload = seatAfkPayload{}
 	case "SEAT_NOT_AFK":
 		a.Payload = seatNotAfkPayload{}
+	case "CHAT":
+		chat := chatPayload{}
+		err := json.Unmarshal(payloadBytes, &chat)
+		if err != nil {
+			return err
+		}
+		a.Payload = chat
+	case "REACTION":
+		reaction := reactionPayload{}
+		err := json.Unmarshal(payloadBytes, &reaction)
+		if err != nil {
+			return err
+		}
+		a.Payload = reaction
 	default:
 		log.Errorf("action.UnmarshalJSON: unexpected type: type = %s", a.Type)
 		a.Payload = undefinedActionPayload{}
@@ -0,0 +1,571 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	// Deals sampled for each play made before the deck ran out.
+	ANALYSIS_SAMPLES = 40
+	// Sampled plays are noisy, smaller losses are not flagged.
+	analysisSampledMargin = 2.0
+)
+
+// gameState is the whole table at a play, every hand face up.
+type gameState struct {
+	players  int
+	lifeSuit string
+	hands    [][]card
+	deck     []card // Top first, the life card is last
+	trick    []card
+	leader   int
+}
+
+func (s gameState) turn() int {
+	return (s.leader + len(s.trick)) % s.players
+}
+
+func (s gameState) clone() gameState {
+	c := s
+	c.hands = make([][]card, len(s.hands))
+	for i := range s.hands {
+		c.hands[i] = slices.Clone(s.hands[i])
+	}
+	c.deck = slices.Clone(s.deck)
+	c.trick = slices.Clone(s.trick)
+	return c
+}
+
+// play puts the card at index of the player in turn on the table, once the
+// trick is complete it returns who won it and the points and everyone draws.
+func (s *gameState) play(index int) (winner, points int, done bool) {
+	turn := s.turn()
+	s.trick = append(s.trick, s.hands[turn][index])
+	s.hands[turn] = slices.Delete(s.hands[turn], index, index+1)
+	if len(s.trick) < s.players {
+		return 0, 0, false
+	}
+	winner = (s.leader + trickWinner(s.trick, s.lifeSuit)) % s.players
+	points = trickPoints(s.trick)
+	s.trick = nil
+	s.leader = winner
+	for i := range s.players {
+		if len(s.deck) == 0 {
+			break
+		}
+		seat := (winner + i) % s.players
+		s.hands[seat] = append(s.hands[seat], s.deck[0])
+		s.deck = s.deck[1:]
+	}
+	return winner, points, true
+}
+
+// key is who holds what, the deck is drawn in order so its size is enough.
+func (s gameState) key() solverKey {
+	key := solverKey{deck: len(s.deck), leader: s.leader}
+	for seat, hand := range s.hands {
+		for _, c := range hand {
+			key.hands[seat] |= 1 << cardId(c)
+		}
+	}
+	return key
+}
+
+// policy is a plain strategy for the sampled playouts, it only looks at the
+// player's hand and the trick: win the trick cheaply when it is worth it,
+// feed points to a partner that is winning it, otherwise throw the cheapest.
+func (s gameState) policy() int {
+	turn := s.turn()
+	hand := s.hands[turn]
+	cheapest := minIndex(hand, func(c card) int {
+		cost := c.score*20 + c.val
+		if c.suitString == s.lifeSuit {
+			cost += 300
+		}
+		return cost
+	})
+	if len(s.trick) == 0 {
+		return cheapest
+	}
+
+	winning := trickWinner(s.trick, s.lifeSuit)
+	winningSeat := (s.leader + winning) % s.players
+	if sideOf(winningSeat, s.players) == sideOf(turn, s.players) {
+		richest := minIndex(hand, func(c card) int {
+			if c.suitString == s.lifeSuit {
+				return 0
+			}
+			return -c.score
+		})
+		if hand[richest].suitString != s.lifeSuit && hand[richest].score > 0 {
+			return richest
+		}
+		return cheapest
+	}
+
+	points := trickPoints(s.trick)
+	taker := minIndex(hand, func(c card) int {
+		if !beats(c, s.trick[winning], s.lifeSuit) {
+			return 1000
+		}
+		if c.suitString == s.lifeSuit {
+			return 100 + c.score*10 + c.val
+		}
+		return -c.score
+	})
+	c := hand[taker]
+	switch {
+	case !beats(c, s.trick[winning], s.lifeSuit):
+		return cheapest
+	case c.suitString != s.lifeSuit || points+c.score >= 10:
+		return taker
+	}
+	return cheapest
+}
+
+func minIndex(hand []card, cost func(card) int) int {
+	best := 0
+	for i := range hand {
+		if cost(hand[i]) < cost(hand[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// playout plays the rest of the game with policy, it returns the points the
+// side takes from here on.
+func (s gameState) playout(side int) int {
+	points := 0
+	for len(s.hands[s.turn()]) > 0 {
+		winner, p, done := s.play(s.policy())
+		if done && sideOf(winner, s.players) == side {
+			points += p
+		}
+	}
+	return points
+}
+
+// sample deals again every card the player in turn can't see, the life card
+// stays at the bottom of the deck.
+func (s gameState) sample(rng *rand.Rand) gameState {
+	seat := s.turn()
+	w := s.clone()
+	var pool []card
+	for other := range w.hands {
+		if other != seat {
+			pool = append(pool, w.hands[other]...)
+		}
+	}
+	hidden := max(len(w.deck)-1, 0)
+	pool = append(pool, w.deck[:hidden]...)
+	rng.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	for other := range w.hands {
+		if other == seat {
+			continue
+		}
+		n := len(w.hands[other])
+		w.hands[other] = pool[:n:n]
+		pool = pool[n:]
+	}
+	copy(w.deck, pool)
+	return w
+}
+
+type solverKey struct {
+	hands  [4]uint64
+	deck   int
+	leader int
+}
+
+// solver is exact once every hand and the order of the deck are known, in a
+// game that is once the deck is empty, in a puzzle from the start. The side
+// maximizes its points and everyone else minimizes them, in 3 player games
+// that is the pessimistic view.
+type solver struct {
+	state gameState
+	side  int
+	memo  map[solverKey]int
+}
+
+func newSolver(s gameState) *solver {
+	return &solver{
+		state: s.clone(),
+		side:  sideOf(s.turn(), s.players),
+		memo:  map[solverKey]int{},
+	}
+}
+
+// value is the side's points from the current position with best play.
+func (s *solver) value() int {
+	st := &s.state
+	turn := st.turn()
+	if len(st.hands[turn]) == 0 {
+		return 0
+	}
+	var key solverKey
+	if len(st.trick) == 0 {
+		key = st.key()
+		if v, ok := s.memo[key]; ok {
+			return v
+		}
+	}
+
+	maximize := sideOf(turn, st.players) == s.side
+	best := 0
+	for i := range st.hands[turn] {
+		v := s.after(i)
+		if i == 0 || (maximize && v > best) || (!maximize && v < best) {
+			best = v
+		}
+	}
+
+	if len(st.trick) == 0 {
+		s.memo[key] = best
+	}
+	return best
+}
+
+// after is the value once the player in turn plays the card at index, the
+// state is restored before returning.
+func (s *solver) after(index int) int {
+	st := &s.state
+	turn := st.turn()
+	hand := st.hands[turn]
+	st.hands[turn] = slices.Concat(hand[:index], hand[index+1:])
+	st.trick = append(st.trick, hand[index])
+
+	v := 0
+	if len(st.trick) == st.players {
+		trick, leader, deck, hands := st.trick, st.leader, st.deck, slices.Clone(st.hands)
+		winner := (leader + trickWinner(trick, st.lifeSuit)) % st.players
+		if sideOf(winner, st.players) == s.side {
+			v += trickPoints(trick)
+		}
+		st.trick, st.leader = nil, winner
+		for i := range st.players {
+			if len(st.deck) == 0 {
+				break
+			}
+			seat := (winner + i) % st.players
+			st.hands[seat] = append(slices.Clip(st.hands[seat]), st.deck[0])
+			st.deck = st.deck[1:]
+		}
+		v += s.value()
+		st.trick, st.leader, st.deck = trick, leader, deck
+		copy(st.hands, hands)
+	} else {
+		v = s.value()
+	}
+
+	st.trick = st.trick[:len(st.trick)-1]
+	st.hands[turn] = hand
+	return v
+}
+
+// evaluate is what each card in the hand of the player in turn is worth to
+// their side, exact when the deck is empty.
+func evaluate(s gameState, rng *rand.Rand) (values []float64, exact bool) {
+	hand := s.hands[s.turn()]
+	values = make([]float64, len(hand))
+	if len(s.deck) == 0 {
+		sv := newSolver(s)
+		for i := range hand {
+			values[i] = float64(sv.after(i))
+		}
+		return values, true
+	}
+
+	side := sideOf(s.turn(), s.players)
+	for range ANALYSIS_SAMPLES {
+		w := s.sample(rng)
+		for i := range hand {
+			p := w.clone()
+			winner, points, done := p.play(i)
+			if done && sideOf(winner, p.players) == side {
+				values[i] += float64(points)
+			}
+			values[i] += float64(p.playout(side))
+		}
+	}
+	for i := range values {
+		values[i] /= float64(ANALYSIS_SAMPLES)
+	}
+	return values, false
+}
+
+// slot is a place in a player's hand, what it held is only known once it is
+// played, or earlier for the slot that took the life card in a swap.
+type slot struct {
+	card    card
+	played  bool
+	changes []slotChange // Oldest first
+}
+
+type slotChange struct {
+	at     int
+	before card
+}
+
+// at is the slot's card after the action at index i.
+func (sl *slot) at(i int) card {
+	for _, change := range sl.changes {
+		if change.at > i {
+			return change.before
+		}
+	}
+	return sl.card
+}
+
+type slotDraw struct {
+	at   int
+	slot *slot
+}
+
+type slotSwap struct {
+	at       int
+	hand     []*slot
+	old, new card
+}
+
+type decision struct {
+	at     int
+	seat   int
+	hands  [][]*slot
+	trick  []card
+	leader int
+	life   card
+	index  int
+}
+
+type moveAnalysis struct {
+	Seat  int
+	Card  card
+	Best  card
+	Lost  float64
+	Exact bool
+}
+
+func (m moveAnalysis) flagged() bool {
+	if m.Exact {
+		return m.Lost > 0
+	}
+	return m.Lost >= analysisSampledMargin
+}
+
+// gameAnalysis has a move for each card played, in order.
+type gameAnalysis struct {
+	players []string
+	moves   []moveAnalysis
+}
+
+// analyzeGame rebuilds every hand from the action log, each card played is
+// eventually seen and its index tells which place in the hand it left, the
+// server removes the card and the draws go at the end.
+func analyzeGame(actions []action) (gameAnalysis, error) {
+	var (
+		analysis  gameAnalysis
+		players   int
+		handSize  = variants[0].handSize
+		lifeSuit  string
+		life      card
+		leader    int
+		trick     []card
+		hands     [][]*slot
+		draws     []slotDraw
+		swaps     []slotSwap
+		decisions []decision
+	)
+	for i, a := range actions {
+		switch payload := a.Payload.(type) {
+		case gameConfigPayload:
+			handSize = payload.variant().handSize
+		case gameStartedPayload:
+			players = len(payload.Seats)
+			analysis.players = seatNames(payload.Seats)
+			hands = make([][]*slot, players)
+			for seat := range hands {
+				for range handSize {
+					hands[seat] = append(hands[seat], &slot{})
+				}
+			}
+			leader = payload.StartingSeat
+		case bottomCardSelectedPayload:
+			life = payload.bottomCard
+			lifeSuit = life.suitString
+		case swapBottomCardPayload:
+			if hands == nil {
+				return analysis, errors.New("the game never started")
+			}
+			turn := (leader + len(trick)) % players
+			swapped := newBottomCard(life, payload.Card)
+			swaps = append(swaps, slotSwap{i, slices.Clone(hands[turn]), life, swapped})
+			life = swapped
+		case cardDrawnPayload:
+			if payload.Seat < 0 || payload.Seat >= players {
+				return analysis, errors.New("a card was drawn by a missing seat")
+			}
+			sl := &slot{}
+			hands[payload.Seat] = append(hands[payload.Seat], sl)
+			draws = append(draws, slotDraw{i, sl})
+		case cardPlayedPayload:
+			if hands == nil {
+				return analysis, errors.New("the game never started")
+			}
+			if payload.Seat < 0 || payload.Seat >= players ||
+				payload.Index < 0 || payload.Index >= len(hands[payload.Seat]) {
+				return analysis, errors.New("a card was played from outside the hand")
+			}
+			if payload.Seat != (leader+len(trick))%players {
+				return analysis, fmt.Errorf("seat %d played out of turn", payload.Seat)
+			}
+			d := decision{
+				at:     i,
+				seat:   payload.Seat,
+				trick:  slices.Clone(trick),
+				leader: leader,
+				life:   life,
+				index:  payload.Index,
+			}
+			for _, hand := range hands {
+				d.hands = append(d.hands, slices.Clone(hand))
+			}
+			decisions = append(decisions, d)
+
+			hand := hands[payload.Seat]
+			hand[payload.Index].card = payload.card
+			hand[payload.Index].played = true
+			hands[payload.Seat] = slices.Delete(slices.Clone(hand), payload.Index, payload.Index+1)
+			trick = append(trick, payload.card)
+		case turnWonPayload:
+			leader = payload.Seat
+			trick = nil
+		}
+	}
+	for _, hand := range hands {
+		if len(hand) > 0 {
+			return analysis, errors.New("the game is not over")
+		}
+	}
+
+	// The slot that took the life card held the swapped card until then,
+	// later swaps first so each one sees the slots as they were after it.
+	for _, swap := range slices.Backward(swaps) {
+		i := slices.IndexFunc(swap.hand, func(sl *slot) bool {
+			return sameCard(sl.at(swap.at), swap.old)
+		})
+		if i == -1 {
+			return analysis, errors.New("a swap doesn't match the hands")
+		}
+		sl := swap.hand[i]
+		sl.changes = slices.Insert(sl.changes, 0, slotChange{swap.at, swap.new})
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2)) // The same analysis every time
+	for _, d := range decisions {
+		s := gameState{
+			players:  players,
+			lifeSuit: lifeSuit,
+			hands:    make([][]card, players),
+			trick:    d.trick,
+			leader:   d.leader,
+		}
+		for seat, hand := range d.hands {
+			for _, sl := range hand {
+				s.hands[seat] = append(s.hands[seat], sl.at(d.at))
+			}
+		}
+		for _, draw := range draws {
+			if draw.at > d.at {
+				s.deck = append(s.deck, draw.slot.at(draw.at))
+			}
+		}
+		if len(s.deck) > 0 {
+			s.deck[len(s.deck)-1] = d.life
+		}
+
+		hand := s.hands[d.seat]
+		if d.seat != s.turn() || d.index >= len(hand) {
+			return analysis, fmt.Errorf("seat %d's play doesn't match the hands", d.seat)
+		}
+		values, exact := evaluate(s, rng)
+		best := 0
+		for i := range values {
+			if values[i] > values[best] {
+				best = i
+			}
+		}
+		analysis.moves = append(analysis.moves, moveAnalysis{
+			Seat:  d.seat,
+			Card:  hand[d.index],
+			Best:  hand[best],
+			Lost:  values[best] - values[d.index],
+			Exact: exact,
+		})
+	}
+	return analysis, nil
+}
+
+type analysisMsg struct {
+	analysis gameAnalysis
+	err      error
+}
+
+// analyze runs on the replay's log, the client side actions are skipped.
+func (m gsModel) analyze() tea.Cmd {
+	actions := slices.Clone(m.actionCache.actions)
+	return func() tea.Msg {
+		analysis, err := analyzeGame(actions)
+		return analysisMsg{analysis, err}
+	}
+}
+
+func (m gsModel) moveNote(move moveAnalysis) string {
+	if !move.flagged() {
+		return ""
+	}
+	note := fmt.Sprintf(" (-%.1f, best %s", move.Lost, move.Best.renderCard(m.userGlobal.renderEmoji))
+	if !move.Exact {
+		note += ", sampled"
+	}
+	return note + ")"
+}
+
+// analysisView takes the help line's place, the last play and the points
+// each player has lost so far.
+func (m gsModel) analysisView() string {
+	if m.analysisErr != nil {
+		return "No analysis, " + m.analysisErr.Error()
+	}
+	if m.analysis == nil {
+		return "Analyzing the game..."
+	}
+	lost := make([]float64, len(m.analysis.players))
+	for _, move := range m.analysis.moves[:min(m.plays, len(m.analysis.moves))] {
+		if move.flagged() && move.Seat < len(lost) {
+			lost[move.Seat] += move.Lost
+		}
+	}
+	var totals []string
+	for seat, name := range m.analysis.players {
+		totals = append(totals, fmt.Sprintf("%s -%.1f", name, lost[seat]))
+	}
+	s := "Analysis: " + strings.Join(totals, ", ")
+	if m.plays > 0 && m.plays <= len(m.analysis.moves) {
+		move := m.analysis.moves[m.plays-1]
+		note := m.moveNote(move)
+		if note == "" {
+			note = " (best)"
+		}
+		s += fmt.Sprintf(" | last %s%s", move.Card.renderCard(m.userGlobal.renderEmoji), note)
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func cards(s ...string) []card {
+	var c []card
+	for _, cs := range s {
+		c = append(c, newCard(cs))
+	}
+	return c
+}
+
+func TestTrickWinner(t *testing.T) {
+	tests := []struct {
+		trick []string
+		want  int
+	}{
+		{[]string{"COPA:3", "COPA:1"}, 1},
+		{[]string{"COPA:1", "COPA:3"}, 0},
+		// Another suit never takes the trick, a life card always does.
+		{[]string{"COPA:4", "BASTO:1"}, 0},
+		{[]string{"COPA:1", "ORO:2"}, 1},
+		{[]string{"COPA:1", "ORO:2", "ORO:4", "COPA:3"}, 2},
+	}
+	for _, tt := range tests {
+		if got := trickWinner(cards(tt.trick...), "ORO"); got != tt.want {
+			t.Errorf("%v is won by %d, want %d", tt.trick, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateEndgame(t *testing.T) {
+	// bo answers ana's 3 of cups, the ace takes the trick and the last one,
+	// the 5 of clubs gives away both.
+	s := gameState{
+		players:  2,
+		lifeSuit: "ORO",
+		hands:    [][]card{cards("BASTO:4"), cards("COPA:1", "BASTO:5")},
+		trick:    cards("COPA:3"),
+	}
+	values, exact := evaluate(s, rand.New(rand.NewPCG(1, 2)))
+	if !exact || !slices.Equal(values, []float64{21, 0}) {
+		t.Errorf("got %v exact %v, want [21 0] exact", values, exact)
+	}
+}
+
+func TestSolverTeams(t *testing.T) {
+	// ana leads and cy, ana's partner, holds the only life card.
+	s := gameState{
+		players:  4,
+		lifeSuit: "ORO",
+		hands:    [][]card{cards("COPA:4"), cards("COPA:1"), cards("ORO:2"), cards("COPA:3")},
+	}
+	if got := newSolver(s).value(); got != 21 {
+		t.Errorf("got %d, want the whole trick", got)
+	}
+}
+
+// playedGame deals a 2 player game and plays it with the playout policy, the
+// log is what the server sends.
+func playedGame(seed uint64) []action {
+	deck := fullDeck()
+	rng := rand.New(rand.NewPCG(seed, seed))
+	rng.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
+	s := gameState{players: 2, lifeSuit: deck[len(deck)-1].suitString}
+	for range s.players {
+		s.hands = append(s.hands, slices.Clone(deck[:3]))
+		deck = deck[3:]
+	}
+	s.deck = deck
+	life := deck[len(deck)-1]
+
+	actions := []action{
+		{Type: "GAME_CONFIG", Payload: gameConfigPayload{GameId: "g1", MaxPlayers: 2}},
+		{Type: "GAME_STARTED", Payload: gameStartedPayload{Seats: []seat{{Seat: 0, Username: "ana"}, {Seat: 1, Username: "bo"}}}},
+		{Type: "BOTTOM_CARD_SELECTED", Payload: bottomCardSelectedPayload{BottomCard: life.cardString(), bottomCard: life}},
+	}
+	for len(s.hands[s.turn()]) > 0 {
+		turn, index := s.turn(), s.policy()
+		c := s.hands[turn][index]
+		actions = append(actions, action{Type: "CARD_PLAYED", Payload: cardPlayedPayload{Seat: turn, Index: index, Card: c.cardString(), card: c}})
+		drawn := len(s.deck)
+		winner, _, done := s.play(index)
+		if !done {
+			continue
+		}
+		actions = append(actions, action{Type: "TURN_WON", Payload: turnWonPayload{Seat: winner}})
+		for i := range min(drawn, s.players) {
+			seat := (winner + i) % s.players
+			actions = append(actions, action{Type: "CARD_DRAWN", Payload: cardDrawnPayload{Seat: seat}})
+		}
+	}
+	return actions
+}
+
+func TestAnalyzeGame(t *testing.T) {
+	actions := playedGame(7)
+	analysis, err := analyzeGame(actions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analysis.moves) != 40 {
+		t.Fatalf("got %d moves, want one per card", len(analysis.moves))
+	}
+
+	var played []action
+	for _, a := range actions {
+		if a.Type == "CARD_PLAYED" {
+			played = append(played, a)
+		}
+	}
+	for i, move := range analysis.moves {
+		p := played[i].Payload.(cardPlayedPayload)
+		if move.Seat != p.Seat || !sameCard(move.Card, p.card) {
+			t.Errorf("move %d is %d %s, want %d %s", i, move.Seat, move.Card.cardString(), p.Seat, p.Card)
+		}
+		if move.Lost < 0 {
+			t.Errorf("move %d lost %v, the best move can't be worse", i, move.Lost)
+		}
+		// The last 3 tricks are played with the deck empty.
+		if move.Exact != (i >= 34) {
+			t.Errorf("move %d exact is %v", i, move.Exact)
+		}
+	}
+}
+
+func TestAnalyzeGameNotOver(t *testing.T) {
+	actions := playedGame(7)
+	if _, err := analyzeGame(actions[:len(actions)/2]); err == nil {
+		t.Error("half a game was analyzed")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 )
 
@@ -50,12 +51,20 @@ func newCard(cardString string) card {
 	var card card
 
 	halves := strings.Split(cardString, ":")
+	if len(halves) != 2 {
+		log.Error("Card without a number.", "card", cardString)
+		return card
+	}
 	suitString := halves[0]
 	num, err := strconv.Atoi(halves[1])
 	if err != nil {
 		log.Error("Error parsing str to int for hand request.")
 		return card
 	}
+	if num < 1 || num > len(CARDS_WITHOUT_SKIP) {
+		log.Error("Card number out of range.", "card", cardString)
+		return card
+	}
 
 	index := num - 1
 
@@ -97,15 +106,56 @@ func (m *card) renderCard(renderEmoji bool) string {
 	}
 }
 
-func newBottomCard(c card) card {
-	// only a 2 of the same suit could do this
-	swapNum := 2
-	index := swapNum - 1
-	return card{
-		emojiSuit: c.emojiSuit,
-		charSuit:  c.charSuit,
-		num:       swapNum,
-		val:       CARDS_WITHOUT_SKIP[index][CARD_VALUE_INDEX],
-		score:     CARDS_WITHOUT_SKIP[index][CARD_SCORE_INDEX],
+// renderCardBack is a face down card as wide as renderCard.
+func renderCardBack() string {
+	return "[░░░░░]"
+}
+
+func renderLargeCardBack(style lipgloss.Style) string {
+	innerWidth := largeCardWidth - 2
+	row := strings.Repeat("░", innerWidth)
+	return style.Render(strings.Repeat(row+"\n", largeCardHeight-3) + row)
+}
+
+// newBottomCard is the card that took the life card's place, when the server
+// doesn't say which it was the 2, the original house rule.
+func newBottomCard(c card, swapped string) card {
+	if swapped != "" {
+		return newCard(swapped)
+	}
+	return newCard(fmt.Sprintf("%s:%d", c.suitString, 2))
+}
+
+// cardString is the card as the server writes it, "ORO:7".
+func (m card) cardString() string {
+	return fmt.Sprintf("%s:%d", m.suitString, m.num)
+}
+
+const (
+	largeCardWidth  = 7 // 5 inner + 2 borders
+	largeCardHeight = 5 // 3 inner + 2 borders
+)
+
+var (
+	largeCardStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240"))
+	selectedLargeCardStyle = largeCardStyle.
+				BorderForeground(lipgloss.Color("69"))
+)
+
+// renderLargeCard draws the card as a bordered card face with the number on
+// the top left and bottom right corners and the suit pip in the middle.
+func (m *card) renderLargeCard(renderEmoji bool, style lipgloss.Style) string {
+	innerWidth := largeCardWidth - 2
+	suit := m.charSuit
+	if renderEmoji {
+		suit = m.emojiSuit
 	}
+	face := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.PlaceHorizontal(innerWidth, lipgloss.Left, strconv.Itoa(m.num)),
+		lipgloss.PlaceHorizontal(innerWidth, lipgloss.Center, suit),
+		lipgloss.PlaceHorizontal(innerWidth, lipgloss.Right, strconv.Itoa(m.num)),
+	)
+	return style.Render(face)
 }
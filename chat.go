@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	chatPollInterval = time.Second
+	chatMaxLength    = 200
+	chatMaxMessages  = 100
+	// Rate limit, at most chatBurst messages every chatWindow.
+	chatBurst  = 3
+	chatWindow = time.Second * 5
+
+	chatNameStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("69"))
+	chatTimeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+	chatNoticeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9"))
+	chatPaneStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(inactiveColor)
+)
+
+// chatBackend is where chat messages are sent to and read from, the game
+// server or the offline stand-in.
+type chatBackend interface {
+	chatRequest(room gameId, since int) []chatMessage
+	sendChatRequest(room gameId, chat sendChat) bool
+}
+
+// chatKeyMap is only used while typing, Talk is the screen's key to start
+// typing and is shown as a hint.
+type chatKeyMap struct {
+	Talk   key.Binding
+	Send   key.Binding
+	Cancel key.Binding
+}
+
+var chatKeys = chatKeyMap{
+	Send: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "send"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "stop chatting"),
+	),
+}
+
+type chatModel struct {
+	room     gameId
+	messages []chatMessage
+	lastId   int
+	input    textinput.Model
+	sent     []time.Time
+	notice   string
+	backend  chatBackend
+	poller   *chatPoller
+	keys     chatKeyMap
+}
+
+// chatPoller is shared by the copies of a chatModel, screens pass it by value
+// and Init can't keep what join changes. Each join starts a new generation,
+// replies to an older one or to another screen's chat are dropped and not
+// polled again.
+type chatPoller struct {
+	generation int
+}
+
+type chatPolledMsg struct {
+	poller     *chatPoller
+	generation int
+	room       gameId
+	messages   []chatMessage
+}
+
+type chatSentMsg struct {
+	ok bool
+}
+
+func newChat(backend chatBackend, talk key.Binding) chatModel {
+	input := textinput.New()
+	input.Placeholder = "Say something..."
+	input.CharLimit = chatMaxLength
+	input.Prompt = "> "
+	keys := chatKeys
+	keys.Talk = talk
+	return chatModel{
+		input:   input,
+		backend: backend,
+		poller:  &chatPoller{},
+		keys:    keys,
+	}
+}
+
+// join sets the room the chat polls and restarts polling, the screen calls it
+// every time it becomes the active one.
+func (m *chatModel) join(room gameId) tea.Cmd {
+	m.room = room
+	m.poller.generation++
+	if room.GameId == "" {
+		return nil
+	}
+	return m.poll()
+}
+
+func (m chatModel) poll() tea.Cmd {
+	poller, generation := m.poller, m.poller.generation
+	return tea.Every(chatPollInterval, func(t time.Time) tea.Msg {
+		return chatPolledMsg{
+			poller:     poller,
+			generation: generation,
+			room:       m.room,
+			messages:   m.backend.chatRequest(m.room, m.lastId),
+		}
+	})
+}
+
+func (m chatModel) focused() bool {
+	return m.input.Focused()
+}
+
+func (m *chatModel) focus() tea.Cmd {
+	m.notice = ""
+	return m.input.Focus()
+}
+
+// add appends messages skipping the ones already seen, live games get the
+// same message from polling and from the action log.
+func (m *chatModel) add(messages ...chatMessage) {
+	for _, msg := range messages {
+		if slices.ContainsFunc(m.messages, func(c chatMessage) bool { return c.Id == msg.Id }) {
+			continue
+		}
+		m.messages = append(m.messages, msg)
+		m.lastId = max(m.lastId, msg.Id)
+	}
+	if len(m.messages) > chatMaxMessages {
+		m.messages = m.messages[len(m.messages)-chatMaxMessages:]
+	}
+}
+
+// rateLimited forgets the sends older than the window and reports if one
+// more message would go over the burst.
+func (m *chatModel) rateLimited(now time.Time) bool {
+	m.sent = slices.DeleteFunc(m.sent, func(t time.Time) bool {
+		return now.Sub(t) > chatWindow
+	})
+	return len(m.sent) >= chatBurst
+}
+
+func (m chatModel) send(text string) tea.Cmd {
+	room := m.room
+	return func() tea.Msg {
+		return chatSentMsg{ok: m.backend.sendChatRequest(room, sendChat{Message: text})}
+	}
+}
+
+func (m chatModel) Update(msg tea.Msg) (chatModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case chatPolledMsg:
+		if msg.poller != m.poller || msg.generation != m.poller.generation {
+			return m, nil
+		}
+		if msg.room == m.room {
+			m.add(msg.messages...)
+		}
+		return m, m.poll()
+	case chatSentMsg:
+		if !msg.ok {
+			m.notice = "Message not sent."
+		}
+	case tea.KeyMsg:
+		if !m.focused() {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Cancel):
+			m.input.Blur()
+			return m, nil
+		case key.Matches(msg, m.keys.Send):
+			text := strings.TrimSpace(m.input.Value())
+			if text == "" || m.room.GameId == "" {
+				return m, nil
+			}
+			now := time.Now()
+			if m.rateLimited(now) {
+				m.notice = "Slow down, wait a few seconds."
+				return m, nil
+			}
+			m.sent = append(m.sent, now)
+			m.notice = ""
+			m.input.Reset()
+			return m, m.send(text)
+		}
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// View renders the newest messages that fit above the input line.
+func (m chatModel) View(width, height int) string {
+	bottom := m.input.View()
+	if m.notice != "" {
+		bottom = chatNoticeStyle.Render(m.notice)
+	} else if !m.focused() {
+		bottom = chatTimeStyle.Render(fmt.Sprintf("%s to chat", m.keys.Talk.Help().Key))
+	}
+	lineStyle := lipgloss.NewStyle().Width(width)
+
+	var lines []string
+	for i := len(m.messages) - 1; i >= 0 && len(lines) < height-1; i-- {
+		msg := m.messages[i]
+		line := lineStyle.Render(fmt.Sprintf("%s %s: %s",
+			chatTimeStyle.Render(msg.Sent.Local().Format("15:04")),
+			chatNameStyle.Render(msg.Username),
+			msg.Message,
+		))
+		// Walking backwards, so the wrapped lines go in reverse too.
+		wrapped := strings.Split(line, "\n")
+		slices.Reverse(wrapped)
+		lines = append(lines, wrapped...)
+	}
+	lines = lines[:min(len(lines), max(height-1, 0))]
+	slices.Reverse(lines)
+
+	s := lipgloss.PlaceVertical(max(height-1, 0), lipgloss.Bottom, strings.Join(lines, "\n"))
+	return lipgloss.JoinVertical(lipgloss.Left, s, lipgloss.NewStyle().MaxWidth(width).Render(bottom))
+}
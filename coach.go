@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// suitName is the suit as the cheat sheet writes it, "Oro".
+func suitName(suit string) string {
+	return strings.ToUpper(suit[:1]) + strings.ToLower(suit[1:])
+}
+
+func coachCard(c card) string {
+	if c.num == 1 {
+		return "the ace of " + suitName(c.suitString)
+	}
+	return fmt.Sprintf("the %d of %s", c.num, suitName(c.suitString))
+}
+
+// coachLine explains who took the trick with the cheat sheet's rules, against
+// the best of the other cards.
+func coachLine(trick []card, lifeSuit, winnerName string) string {
+	if len(trick) < 2 {
+		return ""
+	}
+	w := trickWinner(trick, lifeSuit)
+	var others []card
+	for i := range trick {
+		if i != w {
+			others = append(others, trick[i])
+		}
+	}
+	winner := trick[w]
+	runner := others[trickWinner(others, lifeSuit)]
+
+	var why string
+	switch {
+	case winner.suitString == lifeSuit && runner.suitString != lifeSuit:
+		why = fmt.Sprintf("%s is the life suit, so %s beats %s",
+			suitName(lifeSuit), coachCard(winner), coachCard(runner))
+	case winner.suitString == runner.suitString:
+		why = fmt.Sprintf("%s outranks %s", coachCard(winner), coachCard(runner))
+		if winner.suitString == lifeSuit {
+			why += ", both of the life suit"
+		}
+	default:
+		why = fmt.Sprintf("%s set the turn suit, %s is neither %s nor the life suit %s",
+			coachCard(winner), coachCard(runner), suitName(winner.suitString), suitName(lifeSuit))
+	}
+
+	points := trickPoints(trick)
+	if points == 0 {
+		return fmt.Sprintf("%s; %s gets no points", why, winnerName)
+	}
+	return fmt.Sprintf("%s; %s gets %d points", why, winnerName, points)
+}
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"cmp"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	dailyBot = "Daily"
+	// The seed is the HMAC-SHA256 of the prefix and the date, YYYY-MM-DD in
+	// UTC, keyed with the daily secret.
+	dailySeedPrefix = "brisca.sh daily "
+)
+
+// dailySecret is BRISCA_DAILYSECRET, or a random one that lasts until a
+// restart.
+var dailySecret = sync.OnceValue(func() []byte {
+	if env.DailySecret != "" {
+		return []byte(env.DailySecret)
+	}
+	log.Warn("No daily secret, the daily deals change on every start")
+	secret := make([]byte, sha256.Size)
+	crand.Read(secret)
+	return secret
+})
+
+// today is the daily deal's date, the same for everyone.
+func today() string {
+	return time.Now().UTC().Format(time.DateOnly)
+}
+
+// dailySeed is secret until the day is over, only its hash is shown before.
+func dailySeed(date string) [sha256.Size]byte {
+	mac := hmac.New(sha256.New, dailySecret())
+	mac.Write([]byte(dailySeedPrefix + date))
+	return [sha256.Size]byte(mac.Sum(nil))
+}
+
+// dailyCommitment is the seed's sha256, the revealed seed is checked
+// against it.
+func dailyCommitment(date string) [sha256.Size]byte {
+	seed := dailySeed(date)
+	return sha256.Sum256(seed[:])
+}
+
+// dailyOver is whether the seed of a day can be revealed.
+func dailyOver(date string) bool {
+	return date < today()
+}
+
+// dailyDeck is fullDeck shuffled by a PCG seeded with the seed's first two
+// big endian words: from the last card down, card i swaps with card
+// Uint64() % (i+1). The player gets the first three cards, Daily the next
+// three, the last card is the life card.
+func dailyDeck(date string) []card {
+	seed := dailySeed(date)
+	rng := rand.New(rand.NewPCG(binary.BigEndian.Uint64(seed[:8]), binary.BigEndian.Uint64(seed[8:16])))
+	deck := fullDeck()
+	for i := len(deck) - 1; i > 0; i-- {
+		j := rng.Uint64() % uint64(i+1)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return deck
+}
+
+func dailyGameId(date string) string {
+	return "daily-" + date
+}
+
+// dailyGame is the day's deal against Daily, it is also the backend.
+type dailyGame struct {
+	date string
+	*localEngine
+}
+
+func newDailyGame(date, username string) *dailyGame {
+	handSize := variants[0].handSize
+	deck := dailyDeck(date)
+	return &dailyGame{
+		date: date,
+		localEngine: newLocalEngine(gameConfigPayload{
+			GameId:     dailyGameId(date),
+			MaxPlayers: 2,
+			Variant:    variants[0].name,
+			SwapRules:  swapPresets[1].rules,
+		}, username, dailyBot,
+			[2][]card{deck[:handSize], deck[handSize : 2*handSize]},
+			deck[2*handSize:]),
+	}
+}
+
+func newDailyGSModel(userGlobal userGlobal, date string) gsModel {
+	m := newGSModel(userGlobal)
+	m.daily = newDailyGame(date, userGlobal.username)
+	m.backend = m.daily
+	return m
+}
+
+// dailyResult is a daily deal, started when the player deals it and
+// finished with the points and the actions to replay it.
+type dailyResult struct {
+	Date     string    `json:"date"`
+	Username string    `json:"username"`
+	Points   int       `json:"points"`
+	Result   string    `json:"result"`
+	Finished time.Time `json:"finished"`
+	Actions  []action  `json:"actions"`
+}
+
+func (r dailyResult) finished() bool {
+	return r.Result != ""
+}
+
+type dailyEntry struct {
+	Rank     int    `json:"rank"`
+	Username string `json:"username"`
+	Points   int    `json:"points"`
+	// Empty for a deal that was started and left.
+	Result string `json:"result"`
+}
+
+func (e dailyEntry) resultName() string {
+	if e.Result == "" {
+		return "unfinished"
+	}
+	return e.Result
+}
+
+type dailyBoard struct {
+	Date    string       `json:"date"`
+	Entries []dailyEntry `json:"entries"`
+	// The player's own entry, nil until the deal is played.
+	Me *dailyEntry `json:"me"`
+}
+
+// dailyBackend serves the daily boards, the game server or the offline
+// stand-in. Each player deals today's deal once, a deal that is left counts
+// as played, and the replays are only sent to players that finished it.
+type dailyBackend interface {
+	dailyBoardRequest(date string) dailyBoard
+	startDailyRequest(date string) bool
+	reportDailyRequest(result dailyResult) bool
+	dailyReplayRequest(date, username string) []action
+}
+
+func (m userGlobal) dailyBackend() dailyBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
+
+// rankDaily ranks by points, then by who finished first, the unfinished
+// deals go last.
+func rankDaily(results []dailyResult) []dailyEntry {
+	results = slices.Clone(results)
+	slices.SortFunc(results, func(a, b dailyResult) int {
+		if a.finished() != b.finished() {
+			if a.finished() {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(b.Points-a.Points, a.Finished.Compare(b.Finished), strings.Compare(a.Username, b.Username))
+	})
+	entries := make([]dailyEntry, len(results))
+	for i, r := range results {
+		entries[i] = dailyEntry{i + 1, r.Username, r.Points, r.Result}
+	}
+	return entries
+}
+
+func (m gsModel) reportDaily(won gameWonPayload) tea.Cmd {
+	if m.daily == nil {
+		return nil
+	}
+	result := dailyResult{
+		Date:     m.daily.date,
+		Username: m.userGlobal.username,
+		Finished: time.Now(),
+		Actions:  m.daily.actions(),
+	}
+	for _, p := range m.gameResult(won).Players {
+		if p.Username == m.userGlobal.username {
+			result.Points, result.Result = p.Points, p.Result
+		}
+	}
+	return func() tea.Msg {
+		m.userGlobal.dailyBackend().reportDailyRequest(result)
+		return nil
+	}
+}
+
+// dailyDealLines is the deal as the daily command prints it, to check a
+// replay against the seed. Only for days that are over.
+func dailyDealLines(date string) []string {
+	seed := dailySeed(date)
+	commitment := dailyCommitment(date)
+	deck := dailyDeck(date)
+	handSize := variants[0].handSize
+	notation := func(cards []card) string {
+		var s []string
+		for _, c := range cards {
+			s = append(s, notationCard(c))
+		}
+		return strings.Join(s, " ")
+	}
+	return []string{
+		"Date: " + date,
+		"Seed: " + hex.EncodeToString(seed[:]),
+		"Seed sha256: " + hex.EncodeToString(commitment[:]),
+		"Your hand: " + notation(deck[:handSize]),
+		dailyBot + "'s hand: " + notation(deck[handSize:2*handSize]),
+		"Deck: " + notation(deck[2*handSize:len(deck)-1]),
+		"Life: " + notationCard(deck[len(deck)-1]),
+	}
+}
+
+type dailyKeyMap struct {
+	Play   key.Binding
+	Replay key.Binding
+	Prev   key.Binding
+	Next   key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+func (k dailyKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Play, k.Replay, k.Prev, k.Next, k.Back}
+}
+
+func (k dailyKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Play, k.Replay, k.Prev, k.Next, k.Back, k.Quit}}
+}
+
+func newDailyKeyMap(km keymapConfig) dailyKeyMap {
+	return dailyKeyMap{
+		Play:   km.binding("daily.play"),
+		Replay: km.binding("daily.replay"),
+		Prev:   km.binding("daily.prev"),
+		Next:   km.binding("daily.next"),
+		Back:   km.binding("daily.back"),
+		Quit:   km.binding("daily.quit"),
+	}
+}
+
+type dailyBoardMsg dailyBoard
+
+type dailyReplayMsg []action
+
+type dailyStartMsg bool
+
+type dailyModel struct {
+	date       string
+	board      dailyBoard
+	status     string
+	table      table.Model
+	keys       dailyKeyMap
+	help       help.Model
+	userGlobal userGlobal
+}
+
+func newDaily(userGlobal userGlobal) dailyModel {
+	m := dailyModel{
+		date: today(),
+		table: table.New(
+			table.WithColumns([]table.Column{
+				{Title: "#", Width: 5},
+				{Title: "Player", Width: 20},
+				{Title: "Points", Width: 8},
+				{Title: "Result", Width: 10},
+			}),
+			table.WithFocused(true),
+		),
+		keys:       newDailyKeyMap(userGlobal.keymap),
+		help:       help.New(),
+		userGlobal: userGlobal,
+	}
+	m.updateKeys()
+	return m
+}
+
+// updateKeys only offers today's deal until it is played, and the replays
+// after.
+func (m *dailyModel) updateKeys() {
+	fetched := m.board.Date == m.date
+	m.keys.Play.SetEnabled(fetched && m.date == today() && m.board.Me == nil)
+	m.keys.Replay.SetEnabled(fetched && m.board.Me != nil && m.board.Me.Result != "")
+	m.keys.Next.SetEnabled(m.date < today())
+}
+
+func (m dailyModel) fetch() tea.Cmd {
+	date := m.date
+	return func() tea.Msg {
+		return dailyBoardMsg(m.userGlobal.dailyBackend().dailyBoardRequest(date))
+	}
+}
+
+func (m dailyModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), m.fetch())
+}
+
+func (m dailyModel) shiftDate(days int) dailyModel {
+	date, err := time.Parse(time.DateOnly, m.date)
+	if err != nil {
+		return m
+	}
+	m.date = date.AddDate(0, 0, days).Format(time.DateOnly)
+	m.board = dailyBoard{}
+	m.status = ""
+	m.table.SetRows(nil)
+	m.updateKeys()
+	return m
+}
+
+func (m dailyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		m.help.Width = msg.Width
+		_, v := docStyle.GetFrameSize()
+		m.table.SetHeight(max(msg.Height-v-6, 3)) // Title, seed, me and help
+
+	case dailyBoardMsg:
+		if msg.Date != "" && msg.Date != m.date {
+			return m, nil // A day that was left already
+		}
+		m.board = dailyBoard(msg)
+		m.board.Date = m.date
+		var rows []table.Row
+		for _, entry := range m.board.Entries {
+			rows = append(rows, table.Row{
+				fmt.Sprint(entry.Rank),
+				entry.Username,
+				fmt.Sprint(entry.Points),
+				entry.resultName(),
+			})
+		}
+		m.table.SetRows(rows)
+		m.table.SetCursor(0)
+		m.updateKeys()
+
+	case dailyReplayMsg:
+		if msg == nil {
+			m.status = "The replay is not available."
+			return m, nil
+		}
+		rgs := newReplayGSModel(m.userGlobal, msg)
+		return rgs, rgs.Init()
+
+	case dailyStartMsg:
+		if !msg {
+			m.status = "You already dealt this deal."
+			return m, m.fetch()
+		}
+		dgs := newDailyGSModel(m.userGlobal, m.date)
+		return dgs, dgs.Init()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		case key.Matches(msg, m.keys.Play):
+			date := m.date
+			return m, func() tea.Msg {
+				return dailyStartMsg(m.userGlobal.dailyBackend().startDailyRequest(date))
+			}
+		case key.Matches(msg, m.keys.Replay):
+			i := m.table.Cursor()
+			if i < 0 || i >= len(m.board.Entries) {
+				return m, nil
+			}
+			date, username := m.date, m.board.Entries[i].Username
+			return m, func() tea.Msg {
+				return dailyReplayMsg(m.userGlobal.dailyBackend().dailyReplayRequest(date, username))
+			}
+		case key.Matches(msg, m.keys.Prev):
+			m = m.shiftDate(-1)
+			return m, m.fetch()
+		case key.Matches(msg, m.keys.Next):
+			m = m.shiftDate(1)
+			return m, m.fetch()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m dailyModel) View() string {
+	commitment := dailyCommitment(m.date)
+	seed := "seed sha256 " + hex.EncodeToString(commitment[:]) + ", revealed once the day is over"
+	if dailyOver(m.date) {
+		seed = "check the seed and the deal with: " + dailyCommand(m.date)
+	}
+	me := "You have not played this deal, replays unlock once you finish it."
+	if m.date != today() && m.board.Me == nil {
+		me = "You did not play this deal."
+	}
+	switch {
+	case m.board.Me != nil && m.board.Me.Result == "":
+		me = "You left this deal, it counts as played."
+	case m.board.Me != nil:
+		me = fmt.Sprintf("You: #%d with %d points, %s", m.board.Me.Rank, m.board.Me.Points, m.board.Me.Result)
+	}
+	if m.status != "" {
+		me = statusMessageStyle(m.status)
+	}
+
+	s := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Daily deal "+m.date),
+		inactiveTabStyle.Render(seed),
+		m.table.View(),
+		me,
+		m.help.View(m.keys),
+	)
+	return docStyle.Render(s)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOfflineDailyOncePerDay(t *testing.T) {
+	s := sessions("ana", "bo")
+	ana, bo := s[0], s[1]
+	date := today()
+
+	if ana.startDailyRequest("2000-01-01") {
+		t.Error("started another day's deal")
+	}
+	if ana.reportDailyRequest(dailyResult{Date: date, Result: resultWin}) {
+		t.Error("reported a deal that wasn't started")
+	}
+	if !ana.startDailyRequest(date) {
+		t.Fatal("could not start today's deal")
+	}
+	if ana.startDailyRequest(date) {
+		t.Error("started today's deal twice")
+	}
+
+	actions := []action{{Type: "GAME_WON", Payload: gameWonPayload{Seat: 0}}}
+	result := dailyResult{Date: date, Username: "bo", Points: 70, Result: resultWin, Finished: time.Now(), Actions: actions}
+	if !ana.reportDailyRequest(result) {
+		t.Fatal("could not report today's deal")
+	}
+	if ana.reportDailyRequest(dailyResult{Date: date, Points: 120, Result: resultWin}) {
+		t.Error("reported today's deal twice")
+	}
+	board := ana.dailyBoardRequest(date)
+	if board.Me == nil || board.Me.Username != "ana" || board.Me.Points != 70 {
+		t.Errorf("the board has %+v for ana, want 70 points for ana", board.Me)
+	}
+
+	if bo.dailyReplayRequest(date, "ana") != nil {
+		t.Error("sent a replay before finishing the deal")
+	}
+	bo.startDailyRequest(date)
+	if bo.dailyReplayRequest(date, "ana") != nil {
+		t.Error("sent a replay to a deal that was started but not finished")
+	}
+	bo.reportDailyRequest(dailyResult{Date: date, Points: 50, Result: resultLoss})
+	if got := bo.dailyReplayRequest(date, "ana"); len(got) != 1 {
+		t.Errorf("got %v, want ana's replay once bo finished", got)
+	}
+}
@@ -11,4 +11,6 @@ var (
 	FullHelp string
 	//go:embed embeddedFiles/makeGameHelp.md
 	MakeGameHelp string
+	//go:embed embeddedFiles/puzzles.json
+	Puzzles string
 )
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	// How often a session reports its presence, the server shows the
+	// account offline after PRESENCE_TTL without a report.
+	PRESENCE_INTERVAL = time.Second * 20
+	PRESENCE_TTL      = time.Minute
+	// Without a key press for this long the session is idle.
+	PRESENCE_IDLE = time.Minute * 5
+	// Invites not answered by then are dropped.
+	INVITE_TTL           = time.Minute * 10
+	INVITE_POLL_INTERVAL = time.Second * 3
+)
+
+const (
+	presenceOnline  = "online"
+	presenceInGame  = "in game"
+	presenceIdle    = "idle"
+	presenceOffline = "offline"
+)
+
+// presenceOrder lists friends that can play first.
+var presenceOrder = []string{presenceOnline, presenceIdle, presenceInGame, presenceOffline}
+
+type friend struct {
+	Username string `json:"username"`
+	Presence string `json:"presence"`
+}
+
+// invite is an invitation to a friend's waiting room.
+type invite struct {
+	Id     int       `json:"id"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	GameId string    `json:"gameId"`
+	Sent   time.Time `json:"sent"`
+}
+
+type sendInvite struct {
+	To     string `json:"to"`
+	GameId string `json:"gameId"`
+}
+
+type answerInvite struct {
+	Id     int  `json:"id"`
+	Accept bool `json:"accept"`
+}
+
+// friendsBackend serves the friends lists, presence and invites, the game
+// server or the offline stand-in. A friends list is one way, adding someone
+// shows their presence and lets you invite them.
+type friendsBackend interface {
+	friendsRequest() []friend
+	addFriendRequest(username string) bool
+	removeFriendRequest(username string) bool
+	presenceRequest(presence string) bool
+	inviteRequest(invite sendInvite) bool
+	invitesRequest() []invite
+	answerInviteRequest(answer answerInvite) bool
+}
+
+func (m userGlobal) friendsBackend() friendsBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
+
+// presenceTracker follows a session's screens and key presses, it is shared
+// by every copy of the session's userGlobal.
+type presenceTracker struct {
+	mu        sync.Mutex
+	inGame    bool
+	lastInput time.Time
+}
+
+func newPresenceTracker() *presenceTracker {
+	return &presenceTracker{lastInput: time.Now()}
+}
+
+// observe is the program's message filter, it only looks at the messages on
+// their way to the screen.
+func (p *presenceTracker) observe(m tea.Model, msg tea.Msg) tea.Msg {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		p.lastInput = time.Now()
+	}
+	switch m := m.(type) {
+	case gsModel:
+		p.inGame = !m.replay
+	case waitingRoomModel, winScreen:
+		p.inGame = true
+	default:
+		p.inGame = false
+	}
+	return msg
+}
+
+func (p *presenceTracker) presence() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch {
+	case p.inGame:
+		return presenceInGame
+	case time.Since(p.lastInput) >= PRESENCE_IDLE:
+		return presenceIdle
+	}
+	return presenceOnline
+}
+
+// report sends the presence every PRESENCE_INTERVAL until the session ends.
+func (p *presenceTracker) report(backend friendsBackend, done <-chan struct{}) {
+	ticker := time.NewTicker(PRESENCE_INTERVAL)
+	defer ticker.Stop()
+	for {
+		backend.presenceRequest(p.presence())
+		select {
+		case <-done:
+			backend.presenceRequest(presenceOffline)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type invitesMsg []invite
+
+// inviteFailedMsg is an accepted invite to a game that can't be joined.
+type inviteFailedMsg struct{}
+
+func (m lobbyModel) pollInvites() tea.Cmd {
+	backend := m.userGlobal.friendsBackend()
+	return func() tea.Msg {
+		return invitesMsg(backend.invitesRequest())
+	}
+}
+
+// answerInvite joins the game right away when the invite is accepted.
+func (m lobbyModel) answerInvite(inv invite, accept bool) tea.Cmd {
+	backend := m.userGlobal.friendsBackend()
+	return func() tea.Msg {
+		backend.answerInviteRequest(answerInvite{Id: inv.Id, Accept: accept})
+		if !accept {
+			return nil
+		}
+		gameId := gameId{GameId: inv.GameId}
+		if !m.userGlobal.rh.joinGameRequest(gameId) {
+			return inviteFailedMsg{}
+		}
+		return joinGameMsg{gameId: gameId}
+	}
+}
+
+// setInvites shows the oldest invite, the list gives up a line for it.
+func (m *lobbyModel) setInvites(invites []invite) {
+	m.invites = invites
+	m.keys.acceptInvite.SetEnabled(len(invites) > 0)
+	m.keys.declineInvite.SetEnabled(len(invites) > 0)
+	m.resize()
+}
+
+func (m *lobbyModel) resize() {
+	h, v := docStyle.GetFrameSize()
+	if len(m.invites) > 0 {
+		v++
+	}
+	m.list.SetSize(m.userGlobal.sizeMsg.Width-h, m.userGlobal.sizeMsg.Height-v)
+}
+
+func (m lobbyModel) inviteView() string {
+	inv := m.invites[0]
+	accept, decline := m.keys.acceptInvite.Help(), m.keys.declineInvite.Help()
+	line := fmt.Sprintf("%s invited you to game %s · %s %s · %s %s",
+		inv.From, inv.GameId, accept.Key, accept.Desc, decline.Key, decline.Desc)
+	if len(m.invites) > 1 {
+		line += fmt.Sprintf(" (%d more)", len(m.invites)-1)
+	}
+	return statusMessageStyle(line)
+}
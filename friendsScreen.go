@@ -0,0 +1,230 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var presenceStyles = map[string]lipgloss.Style{
+	presenceOnline:  lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
+	presenceIdle:    lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+	presenceInGame:  lipgloss.NewStyle().Foreground(lipgloss.Color("69")),
+	presenceOffline: lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+}
+
+type friendsKeyMap struct {
+	Invite key.Binding
+	Add    key.Binding
+	Remove key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+func newFriendsKeyMap(km keymapConfig) friendsKeyMap {
+	return friendsKeyMap{
+		Invite: km.binding("friends.invite"),
+		Add:    km.binding("friends.add"),
+		Remove: km.binding("friends.remove"),
+		Back:   km.binding("friends.back"),
+		Quit:   km.binding("friends.quit"),
+	}
+}
+
+type friendItem friend
+
+func (i friendItem) Title() string {
+	return i.Username
+}
+
+func (i friendItem) Description() string {
+	return presenceStyles[i.Presence].Render("● " + i.Presence)
+}
+
+func (i friendItem) FilterValue() string {
+	return i.Username
+}
+
+type friendsMsg []friend
+
+type friendsTickMsg struct{}
+
+// friendChangedMsg refreshes the list after an add or a remove, with the
+// status to show.
+type friendChangedMsg string
+
+// friendsModel is the friends list with presence, opened from a waiting room
+// it invites friends to the game. It goes back to the screen it was opened
+// from.
+type friendsModel struct {
+	list       list.Model
+	keys       friendsKeyMap
+	input      textinput.Model
+	adding     bool
+	gameId     gameId
+	nextView   tea.Model
+	userGlobal userGlobal
+}
+
+func newFriends(nv tea.Model, userGlobal userGlobal, gameId gameId) friendsModel {
+	keys := newFriendsKeyMap(userGlobal.keymap)
+	keys.Invite.SetEnabled(gameId.GameId != "")
+	friendsList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	friendsList.Styles.Title = titleStyle
+	friendsList.Title = "Friends"
+	if gameId.GameId != "" {
+		friendsList.Title = "Invite to " + gameId.GameId
+	}
+	friendsList.SetStatusBarItemName("friend", "friends")
+	friendsList.SetFilteringEnabled(false)
+	friendsList.DisableQuitKeybindings()
+	friendsList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Invite, keys.Add, keys.Remove, keys.Back}
+	}
+	friendsList.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Invite, keys.Add, keys.Remove, keys.Back, keys.Quit}
+	}
+
+	input := textinput.New()
+	input.Prompt = "Add friend: "
+	input.CharLimit = 25
+
+	return friendsModel{
+		list:       friendsList,
+		keys:       keys,
+		input:      input,
+		gameId:     gameId,
+		nextView:   nv,
+		userGlobal: userGlobal,
+	}
+}
+
+func (m friendsModel) fetch() tea.Cmd {
+	backend := m.userGlobal.friendsBackend()
+	return func() tea.Msg {
+		return friendsMsg(backend.friendsRequest())
+	}
+}
+
+// tick refreshes the presence while the list is open.
+func (m friendsModel) tick() tea.Cmd {
+	return tea.Tick(PRESENCE_INTERVAL/2, func(time.Time) tea.Msg {
+		return friendsTickMsg{}
+	})
+}
+
+func (m friendsModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), m.fetch(), m.tick())
+}
+
+func (m friendsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		h, v := docStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v-1) // The add friend line
+
+	case friendsMsg:
+		friends := slices.Clone(msg)
+		slices.SortStableFunc(friends, func(a, b friend) int {
+			return slices.Index(presenceOrder, a.Presence) - slices.Index(presenceOrder, b.Presence)
+		})
+		var items []list.Item
+		for _, f := range friends {
+			items = append(items, friendItem(f))
+		}
+		return m, m.list.SetItems(items)
+
+	case friendsTickMsg:
+		return m, tea.Batch(m.fetch(), m.tick())
+
+	case friendChangedMsg:
+		return m, tea.Batch(m.fetch(), m.list.NewStatusMessage(statusMessageStyle(string(msg))))
+
+	case tea.KeyMsg:
+		if m.adding {
+			return m.updateAdding(msg)
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			return m.nextView, m.nextView.Init()
+		case key.Matches(msg, m.keys.Add):
+			m.adding = true
+			m.input.Reset()
+			return m, m.input.Focus()
+		case key.Matches(msg, m.keys.Remove):
+			if i, ok := m.list.SelectedItem().(friendItem); ok {
+				backend := m.userGlobal.friendsBackend()
+				return m, func() tea.Msg {
+					if !backend.removeFriendRequest(i.Username) {
+						return friendChangedMsg("Could not remove " + i.Username + ".")
+					}
+					return friendChangedMsg("Removed " + i.Username + ".")
+				}
+			}
+		case key.Matches(msg, m.keys.Invite):
+			if i, ok := m.list.SelectedItem().(friendItem); ok {
+				return m, m.invite(i.Username)
+			}
+		}
+	}
+
+	list, cmd := m.list.Update(msg)
+	m.list = list
+	cmds = append(cmds, cmd)
+	return m, tea.Batch(cmds...)
+}
+
+func (m friendsModel) updateAdding(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.adding = false
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.adding = false
+		m.input.Blur()
+		username := strings.TrimSpace(m.input.Value())
+		if username == "" {
+			return m, nil
+		}
+		backend := m.userGlobal.friendsBackend()
+		return m, func() tea.Msg {
+			if !backend.addFriendRequest(username) {
+				return friendChangedMsg("Could not add " + username + ".")
+			}
+			return friendChangedMsg("Added " + username + ".")
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m friendsModel) invite(username string) tea.Cmd {
+	backend := m.userGlobal.friendsBackend()
+	invite := sendInvite{To: username, GameId: m.gameId.GameId}
+	return func() tea.Msg {
+		if !backend.inviteRequest(invite) {
+			return friendChangedMsg("Could not invite " + username + ".")
+		}
+		return friendChangedMsg("Invited " + username + ".")
+	}
+}
+
+func (m friendsModel) View() string {
+	add := ""
+	if m.adding {
+		add = m.input.View()
+	}
+	return docStyle.Render(lipgloss.JoinVertical(lipgloss.Left, m.list.View(), add))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOfflineInvites(t *testing.T) {
+	s := sessions("ana", "bo")
+	ana, bo := s[0], s[1]
+
+	if ana.inviteRequest(sendInvite{To: "bo", GameId: "g1"}) {
+		t.Error("invited someone that is not a friend")
+	}
+	ana.addFriendRequest("bo")
+	ana.inviteRequest(sendInvite{To: "bo", GameId: "g1"})
+	ana.inviteRequest(sendInvite{To: "bo", GameId: "g1"})
+	invites := bo.invitesRequest()
+	if len(invites) != 1 || invites[0].From != "ana" {
+		t.Fatalf("got %v, want the same game's invites merged into one", invites)
+	}
+	if got := ana.invitesRequest(); len(got) != 0 {
+		t.Errorf("the sender got %v", got)
+	}
+
+	ana.inviteRequest(sendInvite{To: "bo", GameId: "g2"})
+	if got := bo.invitesRequest(); len(got) != 2 {
+		t.Errorf("got %d invites, want one per game", len(got))
+	}
+
+	if ana.answerInviteRequest(answerInvite{Id: invites[0].Id}) {
+		t.Error("answered someone else's invite")
+	}
+	if !bo.answerInviteRequest(answerInvite{Id: invites[0].Id, Accept: true}) {
+		t.Error("could not answer the invite")
+	}
+	if bo.answerInviteRequest(answerInvite{Id: invites[0].Id, Accept: true}) {
+		t.Error("answered the invite twice")
+	}
+}
+
+func TestOfflineInviteTTL(t *testing.T) {
+	s := sessions("ana", "bo")
+	ana, bo := s[0], s[1]
+	ana.addFriendRequest("bo")
+	ana.inviteRequest(sendInvite{To: "bo", GameId: "g1"})
+	ana.inviteRequest(sendInvite{To: "bo", GameId: "g2"})
+
+	ana.store.invites[0].Sent = time.Now().Add(-INVITE_TTL)
+	invites := bo.invitesRequest()
+	if len(invites) != 1 || invites[0].GameId != "g2" {
+		t.Errorf("got %v, want only the invite to g2", invites)
+	}
+}
@@ -44,8 +44,23 @@ var (
 				Background(lipgloss.Color("69"))
 	windowWidthMin  = 80
 	windowHighttMin = 24
+	gsChatHeight    = 6 // 4 lines of chat and 2 borders
+	// Index labels above the large cards in the hand.
+	largeHandHeight = largeCardHeight + 1
 )
 
+// gameBackend runs the game, the game server or a local engine for the
+// tutorial, the daily deal and the puzzles.
+type gameBackend interface {
+	actionsRequest() []action
+	mySeatRequest() mySeat
+	handRequest() []card
+	playCardRequest(index handIndex) bool
+	swapBottomCardRequest(swap swapBottomCard) bool
+	leaveGameRequest() bool
+	reactRequest(react react) bool
+}
+
 type box struct {
 	view  string
 	style lipgloss.Style
@@ -68,7 +83,7 @@ func (m *gsModel) Refresh() tea.Cmd {
 		var fetched []action
 		var msg newActionsMsg
 		if !m.gameOver {
-			fetched = m.userGlobal.rh.actionsRequest()
+			fetched = m.backend.actionsRequest()
 		}
 		msg.actions, msg.gameOver = injectClientActions(fetched)
 		return msg
@@ -126,16 +141,51 @@ type gsModel struct {
 	gameConfig   gameConfigPayload
 	statusBar    statusBarModel
 	userGlobal   userGlobal
+	backend      gameBackend
+	// Only in the tutorial, it is also the backend.
+	tutorial *localEngine
+	// Only in the daily deal, it is also the backend.
+	daily *dailyGame
+	// Only in a puzzle, it is also the backend.
+	puzzle       *puzzleGame
 	help         gameScreenHelpModel
 	cheatSheet   MarkdownModel
 	showCheat    bool
 	gameOver     bool
+	largeCards   bool
+	layout       layoutMode
+	sidePanel    lipgloss.Style
+	events       []string
+	chat         chatModel
+	showChat     bool
+	replay       bool
+	lastReaction time.Time
+	muting       bool
+	lastClick    lastClick
+	// Card waiting for the second press when confirming plays.
+	confirming *card
+	// Card sent as soon as it is our turn.
+	premove *card
+	// Replays only, nil until the analysis is done.
+	analysis     *gameAnalysis
+	analysisErr  error
+	analyzing    bool
+	showAnalysis bool
+	plays        int
+	// Seat that drew the life card, the last card of the deck.
+	lifeHolder int
+	// What the other players hold, once the deck is empty.
+	showOpenHands bool
+	// The last trick explained, shown while coaching.
+	coachLine string
 }
 
 func newReplayGSModel(userGlobal userGlobal, actions []action) gsModel {
 	m := newGSModel(userGlobal)
 
 	m.actionCache.actions, m.gameOver = injectClientActions(actions)
+	m.replay = true
+	m.help.keys.showAnalysis = true
 
 	return m
 }
@@ -143,6 +193,7 @@ func newReplayGSModel(userGlobal userGlobal, actions []action) gsModel {
 func newGSModel(userGlobal userGlobal) gsModel {
 	m := gsModel{
 		userGlobal: userGlobal,
+		backend:    userGlobal.rh,
 	}
 	m.spinner = spinner.New()
 	var boxes [3][3]box
@@ -179,8 +230,11 @@ func newGSModel(userGlobal userGlobal) gsModel {
 	}
 	m.table = newTableModel(userGlobal.renderEmoji)
 	m.statusBar = newStatusBar(m.playerSeats, userGlobal.renderEmoji)
-	m.help = newGSHelp()
+	m.help = newGSHelp(userGlobal.keymap)
 	m.cheatSheet = NewCheatSheetModel()
+	m.layout = layoutStandard
+	m.sidePanel = sidePanelStyle
+	m.chat = newChat(userGlobal.chatBackend(), m.help.keys.Talk)
 	return m
 }
 
@@ -192,7 +246,7 @@ func (m gsModel) Init() tea.Cmd {
 
 func (m gsModel) getMySeat() tea.Cmd {
 	return func() tea.Msg {
-		mySeat := m.userGlobal.rh.mySeatRequest()
+		mySeat := m.backend.mySeatRequest()
 		return mySeat
 	}
 }
@@ -209,6 +263,10 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.cheatSheet.Style = msg.csStyle
+		m.largeCards = msg.largeCards
+		m.table.largeCards = msg.largeCards
+		m.layout = msg.layout
+		m.sidePanel = msg.sidePanel
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.userGlobal.sizeMsg = msg
@@ -225,13 +283,36 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		cmd = m.ProcessAction()
 		cmds = append(cmds, cmd)
+	case chatPolledMsg, chatSentMsg:
+		m.chat, cmd = m.chat.Update(msg)
+		return m, cmd
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.KeyMsg:
+		if m.chat.focused() {
+			m.chat, cmd = m.chat.Update(msg)
+			return m, cmd
+		}
+		if m.muting {
+			m.toggleMute(msg)
+			return m, nil
+		}
+		for i, react := range m.help.keys.React {
+			if key.Matches(msg, react) {
+				return m, m.sendReaction(i)
+			}
+		}
+		for i, play := range m.help.keys.Play[:m.help.keys.handSize] {
+			if key.Matches(msg, play) {
+				return m, m.requestPlay(i)
+			}
+		}
 		switch {
 		case key.Matches(msg, m.help.keys.Quit):
-			m.userGlobal.rh.leaveGameRequest()
+			m.backend.leaveGameRequest()
 			return m, tea.Quit
 		// case "q":
-		// 	m.userGlobal.rh.leaveGameRequest()
+		// 	m.backend.leaveGameRequest()
 		// 	lm := newLobby(m.userGlobal)
 		// 	return lm, lm.Init()
 		case key.Matches(msg, m.help.keys.Left):
@@ -243,27 +324,64 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.selectedCard = (m.selectedCard + 1) % len(m.hand)
 			return m, nil
 		case key.Matches(msg, m.help.keys.Enter):
-			cmd = m.playCard(m.selectedCard)
-			cmds = append(cmds, cmd)
-			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.help.keys.One):
-			cmd = m.playCard(0)
-			cmds = append(cmds, cmd)
-			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.help.keys.Two):
-			cmd = m.playCard(1)
-			cmds = append(cmds, cmd)
-			return m, tea.Batch(cmds...)
-		case key.Matches(msg, m.help.keys.Three):
-			cmd = m.playCard(2)
+			cmd = m.requestPlay(m.selectedCard)
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...)
+		case key.Matches(msg, m.help.keys.Cancel):
+			m.cancelPending()
+			return m, nil
+		case key.Matches(msg, m.help.keys.Confirm):
+			m.userGlobal.confirmPlay = !m.userGlobal.confirmPlay
+			m.confirming = nil
+			m.help.keys.showCancel = m.premove != nil
+			if m.userGlobal.confirmPlay {
+				m.logEvent("Play confirmation on")
+			} else {
+				m.logEvent("Play confirmation off")
+			}
+			return m, nil
+		case key.Matches(msg, m.help.keys.Analysis):
+			if !m.replay {
+				return m, nil
+			}
+			m.showAnalysis = !m.showAnalysis
+			if m.showAnalysis && m.analysis == nil && !m.analyzing {
+				m.analyzing = true
+				return m, m.analyze()
+			}
+			return m, nil
+		case key.Matches(msg, m.help.keys.Coach):
+			m.userGlobal.coach = !m.userGlobal.coach
+			m.coachLine = ""
+			if m.userGlobal.coach {
+				m.logEvent("Coach on")
+			} else {
+				m.logEvent("Coach off")
+			}
+			return m, nil
+		case key.Matches(msg, m.help.keys.OpenHands):
+			m.showOpenHands = !m.showOpenHands
+			return m, nil
 		case key.Matches(msg, m.help.keys.Swap):
 			cmd = m.swapBottomCard()
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...)
 		case key.Matches(msg, m.help.keys.Cheat):
 			m.showCheat = !m.showCheat
+		case key.Matches(msg, m.help.keys.Large):
+			m.userGlobal.largeCards = !m.userGlobal.largeCards
+			return m.updateWindow(m.userGlobal.sizeMsg)
+		case key.Matches(msg, m.help.keys.Chat):
+			m.showChat = !m.showChat
+			return m.updateWindow(m.userGlobal.sizeMsg)
+		case key.Matches(msg, m.help.keys.Mute):
+			m.muting = true
+			return m, nil
+		case key.Matches(msg, m.help.keys.Talk):
+			m.showChat = true
+			cmd = m.chat.focus()
+			model, resize := m.updateWindow(m.userGlobal.sizeMsg)
+			return model, tea.Batch(cmd, resize)
 
 			// case key.Matches(msg, m.help.keys.Help):
 			// 	m.help.help.ShowAll = !m.help.help.ShowAll
@@ -279,6 +397,7 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case updateHandMsg:
 		m.hand = msg.hand
 		m.swapCheck()
+		m.dropLostPremove()
 	case localUpdateHandMsg:
 		m.statusBar.iPlayed = true
 		m.hand = msg.hand
@@ -288,8 +407,12 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case gameConfigPayload:
 		m.actionCache.processed++
 		m.gameConfig = msg
+		m.help.keys.handSize = msg.variant().handSize
 		m.statusBar, cmd = m.statusBar.Update(msg)
 		cmds = append(cmds, cmd)
+		if !m.replay {
+			cmds = append(cmds, m.chat.join(gameId{GameId: msg.GameId}))
+		}
 		if m.gameConfig.MaxPlayers == 3 {
 			m.boxes[1][2].style = m.boxes[1][2].style.BorderStyle(lipgloss.NormalBorder()) // Adding 3rd player box
 		} else if m.gameConfig.MaxPlayers == 4 {
@@ -300,25 +423,32 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.actionCache.processed++
 		m.statusBar, cmd = m.statusBar.Update(msg)
 		cmds = append(cmds, cmd)
-		// Each player draws 3 cards
-		m.table.deckSize -= len(msg.Seats) * 3
+		// Each player draws a hand
+		m.table.deckSize -= len(msg.Seats) * m.gameConfig.variant().handSize
 		if m.gameConfig.MaxPlayers == 3 {
 			m.table.deckSize -= 1
 		}
 		m.table.cardsInPlay = []card{}
-		cmd = m.processSeats(msg.Seats)
+		if m.puzzle != nil {
+			// The position is not a fresh deal
+			m.table.deckSize = len(m.puzzle.Deck)
+		}
+		cmd = m.processSeats(msg.Seats, m.handSizes(len(msg.Seats)))
 		cmds = append(cmds, cmd)
 	case bottomCardSelectedPayload:
 		m.actionCache.processed++
-		m.statusBar.swapCard = newCard(msg.bottomCard.suitString + ":2")
 		m.table.bottomCard = msg.bottomCard
+		m.swapCheck()
 	case gracePeriodEndedPayload:
 		m.actionCache.processed++
 		m.statusBar, cmd = m.statusBar.Update(msg)
 		cmds = append(cmds, cmd)
 	case swapBottomCardPayload:
 		m.actionCache.processed++
-		m.table.bottomCard = newBottomCard(m.table.bottomCard)
+		m.logEvent("%s swapped the life card", m.playerSeats[m.statusBar.turn].name)
+		m.playerSeats[m.statusBar.turn].swaps++
+		m.table.bottomCard = newBottomCard(m.table.bottomCard, msg.Card)
+		m.swapCheck()
 		cmds = append(cmds, m.updateHand(false))
 		m.table, cmd = m.table.Update(msg)
 		cmds = append(cmds, cmd)
@@ -326,17 +456,36 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.actionCache.processed++
 		m.table.deckSize--
 		m.playerSeats[msg.Seat].handSize++
+		if m.table.deckSize == 0 {
+			m.lifeHolder = msg.Seat
+			m.help.keys.showOpenHands = m.canOpenHands()
+		}
 		cmds = append(cmds, m.updateHand(false))
 	case cardPlayedPayload:
 		m.actionCache.processed++
 		m.table.cardsInPlay = append(m.table.cardsInPlay, msg.card)
 		m.playerSeats[msg.Seat].handSize--
+		note := ""
+		if m.analysis != nil && m.plays < len(m.analysis.moves) {
+			note = m.moveNote(m.analysis.moves[m.plays])
+		}
+		m.plays++
+		m.logEvent("%s played %s%s", m.playerSeats[msg.Seat].name, msg.card.renderCard(m.userGlobal.renderEmoji), note)
 	case turnSwitchPayload:
 		m.actionCache.processed++
 		m.statusBar, cmd = m.statusBar.Update(msg)
 		cmds = append(cmds, cmd)
 	case turnWonPayload:
 		m.actionCache.processed++
+		points := 0
+		for i := range m.table.cardsInPlay {
+			points += m.table.cardsInPlay[i].score
+		}
+		m.logEvent("%s won the turn, +%d", m.playerSeats[msg.Seat].name, points)
+		if m.userGlobal.coach {
+			m.coachLine = coachLine(m.table.cardsInPlay, m.table.bottomCard.suitString, m.playerSeats[msg.Seat].name)
+			m.logEvent("%s", m.coachLine)
+		}
 		slices.Reverse(m.table.cardsInPlay)
 		m.playerSeats[msg.Seat].scorePile = append(m.playerSeats[msg.Seat].scorePile, m.table.cardsInPlay...)
 		m.playerSeats[msg.Seat].score = m.playerSeats[msg.Seat].UpdateScore()
@@ -345,13 +494,43 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	case gameWonPayload:
 		m.actionCache.processed++
+		if m.puzzle != nil {
+			return m.finishPuzzle()
+		}
 		ws := newWinScreen(&m.gameConfig, m.playerSeats, &msg, m.userGlobal)
-		return ws, ws.Init()
+		ws.replay = m.replay || m.tutorial != nil || m.daily != nil
+		return ws, tea.Batch(ws.Init(), m.reportTournament(msg), m.reportResult(msg), m.recordHistory(msg), m.reportDaily(msg),
+			m.unlockAchievements(msg))
+	case analysisMsg:
+		m.analyzing = false
+		if msg.err != nil {
+			m.analysisErr = msg.err
+			break
+		}
+		m.analysis = &msg.analysis
+		flagged := 0
+		for _, move := range m.analysis.moves {
+			if move.flagged() {
+				flagged++
+			}
+		}
+		m.logEvent("Analysis done, %d of %d plays flagged", flagged, len(m.analysis.moves))
+	case chatPayload:
+		m.actionCache.processed++
+		m.chat.add(msg.chatMessage)
+	case reactionPayload:
+		m.actionCache.processed++
+		cmds = append(cmds, m.showReaction(msg))
+	case reactionExpiredMsg:
+		if msg.seat < len(m.playerSeats) && m.playerSeats[msg.seat].reactionId == msg.id {
+			m.playerSeats[msg.seat].reaction = ""
+		}
 	case undefinedActionPayload:
 		m.actionCache.processed++
 	case seatAfkPayload:
 		m.actionCache.processed++
 		m.playerSeats[msg.Seat].afk = true
+		m.logEvent("%s is afk", m.playerSeats[msg.Seat].name)
 		m.statusBar, cmd = m.statusBar.Update(msg)
 		cmds = append(cmds, cmd)
 	case seatNotAfkPayload:
@@ -372,17 +551,32 @@ func (m gsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		cmds = append(cmds, m.Refresh())
 	}
+	cmds = append(cmds, m.firePremove())
 
 	return m, tea.Batch(cmds...)
 }
 
 type seatsMsg []playerModel
 
-func (m gsModel) processSeats(seats []seat) tea.Cmd {
+// handSizes are the cards each seat starts with, a puzzle starts mid game.
+func (m gsModel) handSizes(seats int) []int {
+	sizes := make([]int, seats)
+	for i := range sizes {
+		sizes[i] = m.gameConfig.variant().handSize
+	}
+	if m.puzzle != nil {
+		sizes[localSeat] = len(m.puzzle.Hand)
+		sizes[1] = len(m.puzzle.Opponent)
+	}
+	return sizes
+}
+
+// processSeats makes the players' boxes, handSizes are indexed by seat.
+func (m gsModel) processSeats(seats []seat, handSizes []int) tea.Cmd {
 	return func() tea.Msg {
 		var seatsMsg seatsMsg
 		for i := range seats {
-			player := newPlayerModelFromSeat(seats[i], m.userGlobal.renderEmoji)
+			player := newPlayerModelFromSeat(seats[i], handSizes[i], m.userGlobal.renderEmoji)
 			// This part only works because case mySeat: happens first then seatsMsg
 			adjustedSeat := (i - m.statusBar.mySeat + m.gameConfig.MaxPlayers) % m.gameConfig.MaxPlayers
 			log.Debug("gsModel:", "adjustedSeat", adjustedSeat, "i", i, "m.mySeat", m.statusBar.turn, "m.gameConfig.MaxPlayers", m.gameConfig.MaxPlayers)
@@ -404,14 +598,43 @@ func (m gsModel) processSeats(seats []seat) tea.Cmd {
 }
 
 type resizeMsg struct {
-	boxes   [3][3]box
-	csStyle lipgloss.Style
+	boxes      [3][3]box
+	csStyle    lipgloss.Style
+	largeCards bool
+	layout     layoutMode
+	sidePanel  lipgloss.Style
+}
+
+// fitsLargeCards reports if the table and player boxes still have room when
+// the cards are drawn large, the table must fit all 4 cards of a full turn.
+func fitsLargeCards(midWidth, midHeight, thirdHeight int) bool {
+	const tableLines = 4 // "Table:", deck, life card and in play lines.
+	const minPlayerHeight = 3
+	return thirdHeight >= minPlayerHeight &&
+		midHeight >= tableLines+largeCardHeight &&
+		midWidth >= 2+4*largeCardWidth
 }
 
 func (m gsModel) updateWindow(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	return m, func() tea.Msg {
-		wholeWidth := max(msg.Width, windowWidthMin) - 6        // 6 to account for borders
+		layout := pickLayout(msg.Width, msg.Height)
+		gridWidth := msg.Width
+		if layout == layoutWide {
+			gridWidth -= sidePanelWidth
+		}
+		wholeWidth := max(gridWidth, windowWidthMin) - 6        // 6 to account for borders
 		wholeHeight := max(msg.Height, windowHighttMin) - 6 - 3 // Space reserved for bars
+		if m.showChat && layout == layoutStandard {
+			wholeHeight -= gsChatHeight
+		}
+		largeCards := false
+		if m.userGlobal.largeCards && layout != layoutCompact {
+			largeHeight := wholeHeight - (largeHandHeight - 1) // Hand bar grows
+			if fitsLargeCards(wholeWidth-2*(wholeWidth/3), largeHeight-2*(largeHeight/3), largeHeight/3) {
+				wholeHeight = largeHeight
+				largeCards = true
+			}
+		}
 		thirdWidth := wholeWidth / 3
 		thirdHeight := wholeHeight / 3
 		midWidth := wholeWidth - (2 * thirdWidth)
@@ -444,60 +667,131 @@ func (m gsModel) updateWindow(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 		// Blank Bottom Right
 		m.boxes[2][2].style = m.boxes[2][2].style.Width(thirdWidth).Height(thirdHeight)
 
-		wholeWidth = max(msg.Width, windowWidthMin) - 2    // 2 borders, 2?
-		wholeHeight = max(msg.Height, windowHighttMin) - 3 // 2 borders, 1 help bar
+		// Side panel matches the grid height, 6 borders
+		gridHeight := 2*thirdHeight + midHeight + 6
+		m.sidePanel = m.sidePanel.Width(sidePanelWidth - 2).Height(gridHeight - 2)
+
+		wholeWidth = max(msg.Width, windowWidthAbsMin) - 2    // 2 borders, 2?
+		wholeHeight = max(msg.Height, windowHeightAbsMin) - 3 // 2 borders, 1 help bar
 		m.cheatSheet.Style = m.cheatSheet.Style.Width(wholeWidth).Height(wholeHeight)
 
 		return resizeMsg{
-			boxes:   m.boxes,
-			csStyle: m.cheatSheet.Style,
+			boxes:      m.boxes,
+			csStyle:    m.cheatSheet.Style,
+			largeCards: largeCards,
+			layout:     layout,
+			sidePanel:  m.sidePanel,
 		}
 	}
 }
 
 func (m gsModel) View() string {
+	if m.layout == layoutTooSmall {
+		return m.tooSmallView()
+	}
+	help := m.help.View()
+	if m.muting {
+		help = m.mutePrompt()
+	}
+	if m.userGlobal.coach && m.coachLine != "" {
+		help = "Coach: " + m.coachLine
+	}
+	if m.showOpenHands && m.canOpenHands() {
+		help = m.openHandsView()
+	}
+	if m.puzzle != nil {
+		help = m.puzzleView()
+	}
+	if m.showAnalysis {
+		help = m.analysisView()
+	}
+	if m.tutorial != nil && m.layout == layoutCompact {
+		if prompt := m.tutorialView(m.userGlobal.sizeMsg.Width); prompt != "" {
+			help = prompt
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, m.bodyView(), gsHelpStyle.Render(help))
+}
+
+// bodyView is everything above the help line.
+func (m gsModel) bodyView() string {
 	var s string
 
-	if m.showCheat {
+	switch {
+	case m.showCheat:
 		s = lipgloss.JoinVertical(lipgloss.Top, s,
 			m.cheatSheet.Style.Render(m.cheatSheet.View()),
 		)
-	} else {
-		m.boxes[1][1].view = m.table.View(
-			m.boxes[1][1].style.GetWidth(),
-			m.boxes[1][1].style.GetHeight(),
-		)
-
-		for i := range m.gameConfig.MaxPlayers {
-			x := m.playerSeats[i].boxX
-			y := m.playerSeats[i].boxY
-			m.boxes[x][y].view = m.playerSeats[i].View(
-				m.boxes[x][y].style.GetWidth(), m.boxes[x][y].style.GetHeight(),
-			)
-			if m.statusBar.turn == i {
-				m.boxes[x][y].style = m.boxes[x][y].style.
-					BorderForeground(activeColor)
-			} else {
-				m.boxes[x][y].style = m.boxes[x][y].style.
-					BorderForeground(inactiveColor)
-			}
+	case m.layout == layoutCompact:
+		s = m.compactView()
+		if m.showChat {
+			s = lipgloss.JoinVertical(lipgloss.Left, s, m.chatPaneView())
 		}
+	case m.layout == layoutStandard && m.showChat:
+		s = lipgloss.JoinVertical(lipgloss.Left, m.gridView(), m.chatPaneView())
+	default:
+		s = m.gridView()
+		if m.layout == layoutWide {
+			// The grid starts with a blank line, the panel lines up with the boxes.
+			s = lipgloss.JoinHorizontal(lipgloss.Top, s, "\n"+m.sidePanelView())
+		}
+	}
+	return s
+}
+
+// gridView is the 3x3 box grid with the hand and status bars below.
+func (m gsModel) gridView() string {
+	s := m.gridRowsView()
+	s = lipgloss.JoinVertical(lipgloss.Top, s, m.handView())
+	s = lipgloss.JoinVertical(lipgloss.Top, s, lipgloss.JoinHorizontal(lipgloss.Left, m.statusBar.View(m.hand)))
+	return s
+}
 
-		for i := range len(m.boxes) {
-			row := lipgloss.JoinHorizontal(lipgloss.Top,
-				m.boxes[i][0].style.Render(m.boxes[i][0].view),
-				m.boxes[i][1].style.Render(m.boxes[i][1].view),
-				m.boxes[i][2].style.Render(m.boxes[i][2].view),
-			)
-			s = lipgloss.JoinVertical(lipgloss.Top, s, row)
+// gridRowsView renders the 3x3 boxes, starting with a blank line.
+func (m gsModel) gridRowsView() string {
+	var s string
+	m.boxes[1][1].view = m.table.View(
+		m.boxes[1][1].style.GetWidth(),
+		m.boxes[1][1].style.GetHeight(),
+	)
+
+	for i := range m.gameConfig.MaxPlayers {
+		x := m.playerSeats[i].boxX
+		y := m.playerSeats[i].boxY
+		m.boxes[x][y].view = m.playerSeats[i].View(
+			m.boxes[x][y].style.GetWidth(), m.boxes[x][y].style.GetHeight(),
+		)
+		if m.statusBar.turn == i {
+			m.boxes[x][y].style = m.boxes[x][y].style.
+				BorderForeground(activeColor)
+		} else {
+			m.boxes[x][y].style = m.boxes[x][y].style.
+				BorderForeground(inactiveColor)
 		}
-		s = lipgloss.JoinVertical(lipgloss.Top, s, m.handView())
-		s = lipgloss.JoinVertical(lipgloss.Top, s, lipgloss.JoinHorizontal(lipgloss.Left, m.statusBar.View(m.hand)))
 	}
-	s = lipgloss.JoinVertical(lipgloss.Center, s, gsHelpStyle.Render(m.help.View()))
+
+	if m.tutorial != nil {
+		x, y := m.tutorialBox()
+		m.boxes[x][y].view = m.tutorialView(m.boxes[x][y].style.GetWidth())
+	}
+
+	for i := range len(m.boxes) {
+		row := lipgloss.JoinHorizontal(lipgloss.Top,
+			m.boxes[i][0].style.Render(m.boxes[i][0].view),
+			m.boxes[i][1].style.Render(m.boxes[i][1].view),
+			m.boxes[i][2].style.Render(m.boxes[i][2].view),
+		)
+		s = lipgloss.JoinVertical(lipgloss.Top, s, row)
+	}
 	return s
 }
 
+func (m gsModel) chatPaneView() string {
+	width := lipgloss.Width(m.handView())
+	width = max(m.userGlobal.sizeMsg.Width, width) - 2
+	return chatPaneStyle.Width(width).Render(m.chat.View(width, gsChatHeight-2))
+}
+
 func (m *gsModel) Next() {
 	if m.index == len(spinners)-1 {
 		m.index = 0
@@ -507,17 +801,44 @@ func (m *gsModel) Next() {
 }
 
 func (m *gsModel) handView() string {
+	if m.largeCards {
+		return m.largeHandView()
+	}
 	var s string
 	s = "Hand:"
 	for i := range m.hand {
 		card := (m.hand)[i]
-		if m.selectedCard == i {
-			s += fmt.Sprintf("%2d:%s", i+1, selectedCardStyle.Render(card.renderCard(m.userGlobal.renderEmoji)))
+		if m.isPending(card) {
+			s += fmt.Sprintf("%2d:%s", i+1, pendingCardStyle.Render(m.renderHandCard(card)))
+		} else if m.selectedCard == i {
+			s += fmt.Sprintf("%2d:%s", i+1, selectedCardStyle.Render(m.renderHandCard(card)))
 		} else {
-			s += fmt.Sprintf("%2d:%s", i+1, card.renderCard(m.userGlobal.renderEmoji))
+			s += fmt.Sprintf("%2d:%s", i+1, m.renderHandCard(card))
 		}
 	}
-	return s
+	return s + m.pendingHint()
+}
+
+func (m *gsModel) largeHandView() string {
+	cards := []string{"Hand:"}
+	for i := range m.hand {
+		style := largeCardStyle
+		if m.isPending(m.hand[i]) {
+			style = pendingLargeCardStyle
+		} else if m.selectedCard == i {
+			style = selectedLargeCardStyle
+		}
+		face := m.hand[i].renderLargeCard(m.userGlobal.renderEmoji, style)
+		if m.gameConfig.variant().blind {
+			face = renderLargeCardBack(style)
+		}
+		cards = append(cards, lipgloss.JoinVertical(lipgloss.Center,
+			fmt.Sprintf("%d", i+1),
+			face,
+		))
+	}
+	cards = append(cards, m.pendingHint())
+	return lipgloss.JoinHorizontal(lipgloss.Top, cards...)
 }
 
 type updateHandMsg struct {
@@ -536,7 +857,7 @@ func (m *gsModel) updateHand(delay bool) tea.Cmd {
 		if m.gameOver {
 			return nil
 		}
-		newHand := m.userGlobal.rh.handRequest()
+		newHand := m.backend.handRequest()
 
 		return updateHandMsg{newHand}
 	}
@@ -544,22 +865,24 @@ func (m *gsModel) updateHand(delay bool) tea.Cmd {
 
 func (m *gsModel) playCard(index int) tea.Cmd {
 	if m.statusBar.isMyTurn() && m.statusBar.haventPlayed() {
+		// The index is into the hand as it is now.
+		hand := m.hand
 		return func() tea.Msg {
-			handSize := len(m.hand)
+			handSize := len(hand)
 			if handSize <= index {
 				return nil
 			}
 			newHand := []card{}
-			if len(m.hand) != 1 {
-				for i := range m.hand {
+			if len(hand) != 1 {
+				for i := range hand {
 					if i == index {
 						continue
 					}
-					newHand = append(newHand, (m.hand)[i])
+					newHand = append(newHand, hand[i])
 				}
 			}
 			index := handIndex{Index: index}
-			if !m.userGlobal.rh.playCardRequest(index) {
+			if !m.backend.playCardRequest(index) {
 				return nil
 			}
 			return localUpdateHandMsg{newHand}
@@ -570,8 +893,9 @@ func (m *gsModel) playCard(index int) tea.Cmd {
 
 func (m *gsModel) swapBottomCard() tea.Cmd {
 	if m.statusBar.isMyTurn() && m.statusBar.canSwap {
+		swap := swapBottomCard{Card: m.statusBar.swapCard.cardString()}
 		return func() tea.Msg {
-			if !m.userGlobal.rh.swapBottomCardRequest() {
+			if !m.backend.swapBottomCardRequest(swap) {
 				return nil
 			}
 			return nil
@@ -581,9 +905,10 @@ func (m *gsModel) swapBottomCard() tea.Cmd {
 }
 
 func (m *gsModel) swapCheck() {
-	if m.table.deckSize > 1 && slices.ContainsFunc(m.hand, func(c card) bool {
-		return c.num == m.statusBar.swapCard.num && c.charSuit == m.statusBar.swapCard.charSuit
-	}) {
+	// Playing blind you can't know you hold the swap card.
+	swapCard, ok := swapCandidate(m.gameConfig.swapRules(), m.table.bottomCard, m.hand)
+	if !m.gameConfig.variant().blind && m.table.deckSize > 1 && ok {
+		m.statusBar.swapCard = swapCard
 		m.statusBar.canSwap = true
 		m.help.keys.showSwap = true
 	} else {
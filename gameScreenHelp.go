@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,78 +11,96 @@ import (
 // keyMap defines a set of keybindings. To work for help it must satisfy
 // key.Map. It could also very easily be a map[string]key.Binding.
 type gameScreenKeyMap struct {
-	Left     key.Binding
-	Right    key.Binding
-	Enter    key.Binding
-	One      key.Binding
-	Two      key.Binding
-	Three    key.Binding
-	Swap     key.Binding
-	Cheat    key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	showSwap bool
+	Left  key.Binding
+	Right key.Binding
+	Enter key.Binding
+	// Play a card by its position, one per card of the biggest hand.
+	Play      []key.Binding
+	Swap      key.Binding
+	Confirm   key.Binding
+	Cancel    key.Binding
+	Cheat     key.Binding
+	Large     key.Binding
+	Chat      key.Binding
+	Talk      key.Binding
+	React     []key.Binding
+	Mute      key.Binding
+	Analysis  key.Binding
+	OpenHands key.Binding
+	Coach     key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+	showSwap  bool
+	// Only the play keys for the variant's hand are shown.
+	handSize int
+	// A premove or a play waiting for confirmation can be cancelled.
+	showCancel bool
+	// Replays can be analyzed.
+	showAnalysis bool
+	// The other hands can be deduced once the deck is empty.
+	showOpenHands bool
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view. It's part
 // of the key.Map interface.
 func (k gameScreenKeyMap) ShortHelp() []key.Binding {
+	bindings := []key.Binding{k.Left, k.Right, k.Enter, k.Cheat, k.Talk}
 	if k.showSwap {
-		return []key.Binding{k.Left, k.Right, k.Enter, k.Cheat, k.Swap}
-	} else {
-		return []key.Binding{k.Left, k.Right, k.Enter, k.Cheat}
+		bindings = append(bindings, k.Swap)
+	}
+	if k.showCancel {
+		bindings = append(bindings, k.Cancel)
+	}
+	if k.showAnalysis {
+		bindings = append(bindings, k.Analysis)
 	}
+	if k.showOpenHands {
+		bindings = append(bindings, k.OpenHands)
+	}
+	return bindings
 }
 
 // FullHelp returns keybindings for the expanded help view. It's part of the
 // key.Map interface.
 func (k gameScreenKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Left, k.Right, k.Enter, k.One, k.Two, k.Three, k.Swap, k.Cheat, k.Help, k.Quit}, // second column
+		{k.Left, k.Right, k.Enter, k.Swap, k.Confirm, k.Cancel, k.Cheat, k.Large, k.Chat, k.Talk, k.Mute, k.Analysis, k.OpenHands, k.Coach, k.Help, k.Quit}, // first column
+		k.Play[:k.handSize], // second column
+		k.React,             // third column
 	}
 }
 
-var gameScreenKeys = gameScreenKeyMap{
-	Left: key.NewBinding(
-		key.WithKeys("left", "h"),
-		key.WithHelp("←/h", "left"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "right"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "play card"),
-	),
-	One: key.NewBinding(
-		key.WithKeys("1"),
-		key.WithHelp("1", "play card 1"),
-	),
-	Two: key.NewBinding(
-		key.WithKeys("2"),
-		key.WithHelp("2", "play card 2"),
-	),
-	Three: key.NewBinding(
-		key.WithKeys("3"),
-		key.WithHelp("3", "play card 3"),
-	),
-	Swap: key.NewBinding(
-		key.WithKeys("s"),
-		key.WithHelp("s", "swap life card"),
-	),
-	Cheat: key.NewBinding(
-		key.WithKeys("H"),
-		key.WithHelp("H", "cheatsheet"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "help"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("ctrl+c"),
-		key.WithHelp("ctrl+c", "quit"),
-	),
+func newGameScreenKeyMap(km keymapConfig) gameScreenKeyMap {
+	var play []key.Binding
+	for i := range maxHandSize {
+		play = append(play, km.binding(fmt.Sprintf("game.play%d", i+1)))
+	}
+	return gameScreenKeyMap{
+		Play:     play,
+		handSize: variants[0].handSize,
+		Left:     km.binding("game.left"),
+		Right:    km.binding("game.right"),
+		Enter:    km.binding("game.play"),
+		Swap:     km.binding("game.swap"),
+		Confirm:  km.binding("game.confirm"),
+		Cancel:   km.binding("game.cancel"),
+		Cheat:    km.binding("game.cheat"),
+		Large:    km.binding("game.large"),
+		Chat:     km.binding("game.chat"),
+		Talk:     km.binding("game.talk"),
+		React: []key.Binding{
+			km.binding("game.react1"),
+			km.binding("game.react2"),
+			km.binding("game.react3"),
+			km.binding("game.react4"),
+		},
+		Mute:      km.binding("game.mute"),
+		Analysis:  km.binding("game.analysis"),
+		OpenHands: km.binding("game.openHands"),
+		Coach:     km.binding("game.coach"),
+		Help:      km.binding("game.help"),
+		Quit:      km.binding("game.quit"),
+	}
 }
 
 type gameScreenHelpModel struct {
@@ -88,9 +108,9 @@ type gameScreenHelpModel struct {
 	help help.Model
 }
 
-func newGSHelp() gameScreenHelpModel {
+func newGSHelp(km keymapConfig) gameScreenHelpModel {
 	return gameScreenHelpModel{
-		keys: gameScreenKeys,
+		keys: newGameScreenKeyMap(km),
 		help: help.New(),
 	}
 }
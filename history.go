@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	// Games followed as a spectator.
+	resultWatched = "watched"
+	// Games from a replay file, see replayFile.
+	resultImported = "imported"
+)
+
+// historyEntry is a finished game kept so it can be replayed without its id.
+type historyEntry struct {
+	GameId  string            `json:"gameId"`
+	Date    time.Time         `json:"date"`
+	Players []string          `json:"players"`
+	Result  string            `json:"result"`
+	Config  gameConfigPayload `json:"config"`
+	// Imported games are replayed from disk instead of the server.
+	Imported bool `json:"imported,omitempty"`
+}
+
+// historyFiles guards the history files, every session of an account shares
+// the same file.
+var historyFiles sync.Mutex
+
+// historyPath is one JSON file per account in env.History.
+func historyPath(username string) string {
+	return filepath.Join(env.History, url.PathEscape(username)+".json")
+}
+
+// loadHistory returns the account's games, newest first.
+func loadHistory(username string) []historyEntry {
+	historyFiles.Lock()
+	defer historyFiles.Unlock()
+	return readHistory(username)
+}
+
+func readHistory(username string) []historyEntry {
+	var history []historyEntry
+	data, err := os.ReadFile(historyPath(username))
+	if errors.Is(err, fs.ErrNotExist) {
+		return history
+	}
+	if err != nil {
+		log.Error("Could not read the game history", "error", err)
+		return history
+	}
+	err = json.Unmarshal(data, &history)
+	if err != nil {
+		log.Error("Could not parse the game history", "error", err)
+	}
+	return history
+}
+
+func saveHistory(username string, entry historyEntry) bool {
+	historyFiles.Lock()
+	defer historyFiles.Unlock()
+
+	history := readHistory(username)
+	for _, e := range history {
+		if e.GameId == entry.GameId {
+			return true
+		}
+	}
+	history = append([]historyEntry{entry}, history...)
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		log.Error("Could not encode the game history", "error", err)
+		return false
+	}
+	err = os.MkdirAll(env.History, 0o755)
+	if err != nil {
+		log.Error("Could not create the history directory", "error", err)
+		return false
+	}
+	err = os.WriteFile(historyPath(username), data, 0o644)
+	if err != nil {
+		log.Error("Could not write the game history", "error", err)
+		return false
+	}
+	return true
+}
+
+// recordHistory saves the finished game for the player, replays are already
+// in the history and the tutorial has no replay.
+func (m gsModel) recordHistory(won gameWonPayload) tea.Cmd {
+	if m.replay || m.tutorial != nil || m.daily != nil {
+		return nil
+	}
+	entry := historyEntry{
+		GameId: m.gameConfig.GameId,
+		Date:   time.Now(),
+		Result: resultWatched,
+		Config: m.gameConfig,
+	}
+	for _, p := range m.gameResult(won).Players {
+		entry.Players = append(entry.Players, p.Username)
+		if p.Username == m.userGlobal.username {
+			entry.Result = p.Result
+		}
+	}
+	username := m.userGlobal.username
+	return func() tea.Msg {
+		saveHistory(username, entry)
+		return nil
+	}
+}
+
+// opponents are the other players, as the history list shows them.
+func (e historyEntry) opponents(username string) []string {
+	var others []string
+	for _, p := range e.Players {
+		if p != username {
+			others = append(others, p)
+		}
+	}
+	return others
+}
+
+func resultName(result string) string {
+	switch result {
+	case resultWin:
+		return "Won"
+	case resultLoss:
+		return "Lost"
+	case resultTie:
+		return "Tied"
+	case resultImported:
+		return "Imported"
+	}
+	return "Watched"
+}
+
+// historyItem is a list.Item, the username is needed to name the opponents.
+type historyItem struct {
+	historyEntry
+	username string
+}
+
+func (i historyItem) Title() string {
+	return fmt.Sprintf("%s vs %s", resultName(i.Result), strings.Join(i.opponents(i.username), ", "))
+}
+
+func (i historyItem) Description() string {
+	return fmt.Sprintf("%s · %d players · %s · %s",
+		i.Date.Local().Format("2006-01-02 15:04"), i.Config.MaxPlayers, i.Config.variant().name, i.GameId)
+}
+
+// FilterValue is what the search matches, players, date, variant and id.
+func (i historyItem) FilterValue() string {
+	return strings.Join(append(i.Players,
+		i.Date.Local().Format("2006-01-02"), i.Config.variant().name, i.GameId), " ")
+}
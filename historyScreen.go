@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	historyResults      = []string{"", resultWin, resultLoss, resultTie, resultWatched, resultImported}
+	historyPlayerCounts = []int{0, 2, 3, 4}
+)
+
+type historyKeyMap struct {
+	Replay  key.Binding
+	Result  key.Binding
+	Players key.Binding
+	Export  key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+func newHistoryKeyMap(km keymapConfig) historyKeyMap {
+	return historyKeyMap{
+		Replay:  km.binding("history.replay"),
+		Result:  km.binding("history.result"),
+		Players: km.binding("history.players"),
+		Export:  km.binding("history.export"),
+		Back:    km.binding("history.back"),
+		Quit:    km.binding("history.quit"),
+	}
+}
+
+type historyMsg []historyEntry
+
+type historyReplayMsg []action
+
+// historyModel lists the account's finished games, "/" searches them.
+type historyModel struct {
+	list        list.Model
+	keys        historyKeyMap
+	history     []historyEntry
+	result      int
+	playerCount int
+	userGlobal  userGlobal
+}
+
+func newHistory(userGlobal userGlobal) historyModel {
+	keys := newHistoryKeyMap(userGlobal.keymap)
+	historyList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	historyList.Styles.Title = titleStyle
+	historyList.SetStatusBarItemName("game", "games")
+	historyList.DisableQuitKeybindings()
+	historyList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Replay, keys.Result, keys.Players, keys.Back}
+	}
+	historyList.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Replay, keys.Result, keys.Players, keys.Export, keys.Back, keys.Quit}
+	}
+	m := historyModel{
+		list:       historyList,
+		keys:       keys,
+		userGlobal: userGlobal,
+	}
+	m.list.Title = m.title()
+	return m
+}
+
+func (m historyModel) Init() tea.Cmd {
+	username := m.userGlobal.username
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), func() tea.Msg {
+		return historyMsg(loadHistory(username))
+	})
+}
+
+func (m historyModel) title() string {
+	title := "Game history"
+	if result := historyResults[m.result]; result != "" {
+		title += " · " + resultName(result)
+	}
+	if count := historyPlayerCounts[m.playerCount]; count != 0 {
+		title += fmt.Sprintf(" · %d players", count)
+	}
+	return title
+}
+
+// filtered applies the result and player filters, the search is left to the
+// list.
+func (m historyModel) filtered() []list.Item {
+	var items []list.Item
+	for _, entry := range m.history {
+		if result := historyResults[m.result]; result != "" && entry.Result != result {
+			continue
+		}
+		if count := historyPlayerCounts[m.playerCount]; count != 0 && entry.Config.MaxPlayers != count {
+			continue
+		}
+		items = append(items, historyItem{entry, m.userGlobal.username})
+	}
+	return items
+}
+
+func (m historyModel) replay(entry historyEntry) tea.Cmd {
+	username := m.userGlobal.username
+	return func() tea.Msg {
+		if entry.Imported {
+			return historyReplayMsg(loadImportedReplay(username, entry.GameId))
+		}
+		return historyReplayMsg(m.userGlobal.rh.replayRequest(gameId{GameId: entry.GameId}))
+	}
+}
+
+func (m historyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		h, v := docStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v)
+
+	case historyMsg:
+		m.history = msg
+		return m, m.list.SetItems(m.filtered())
+
+	case historyReplayMsg:
+		if msg == nil {
+			return m, m.list.NewStatusMessage(statusMessageStyle("The replay is not available."))
+		}
+		rgs := newReplayGSModel(m.userGlobal, msg)
+		return rgs, rgs.Init()
+
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			if m.list.FilterState() == list.FilterApplied {
+				break // The list clears the search first
+			}
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		case key.Matches(msg, m.keys.Replay):
+			if i, ok := m.list.SelectedItem().(historyItem); ok {
+				return m, m.replay(i.historyEntry)
+			}
+		case key.Matches(msg, m.keys.Export):
+			if i, ok := m.list.SelectedItem().(historyItem); ok {
+				m.list.StatusMessageLifetime = time.Second * 10
+				return m, m.list.NewStatusMessage(statusMessageStyle(exportCommand(m.userGlobal.username, i.GameId)))
+			}
+		case key.Matches(msg, m.keys.Result):
+			m.result = (m.result + 1) % len(historyResults)
+			m.list.Title = m.title()
+			return m, m.list.SetItems(m.filtered())
+		case key.Matches(msg, m.keys.Players):
+			m.playerCount = (m.playerCount + 1) % len(historyPlayerCounts)
+			m.list.Title = m.title()
+			return m, m.list.SetItems(m.filtered())
+		}
+	}
+
+	list, cmd := m.list.Update(msg)
+	m.list = list
+	cmds = append(cmds, cmd)
+	return m, tea.Batch(cmds...)
+}
+
+func (m historyModel) View() string {
+	return docStyle.Render(m.list.View())
+}
@@ -94,8 +94,7 @@ func (m joinGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		joined := m.userGlobal.rh.joinGameRequest(gameId)
 
 		if joined {
-			wrm := newWaitingRoom(m.userGlobal)
-			wrm.list.Title = "GameID: " + gameId.GameId
+			wrm := newWaitingRoom(m.userGlobal, gameId)
 			cmd = wrm.Init()
 			return wrm, cmd
 		} else {
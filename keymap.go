@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keymapConfig is a preset plus the actions the user rebound, it is saved per
+// account. The zero value is the default preset.
+type keymapConfig struct {
+	Preset    string              `json:"preset"`
+	Overrides map[string][]string `json:"overrides"`
+}
+
+// keymapBackend is where the keymap is saved, the game server or the offline
+// stand-in.
+type keymapBackend interface {
+	keymapRequest() keymapConfig
+	saveKeymapRequest(km keymapConfig) bool
+}
+
+// keyAction is a rebindable action, ids are prefixed with the screen they
+// belong to, keys only conflict within the same screen.
+type keyAction struct {
+	id   string
+	help string
+	keys []string // Default preset
+}
+
+var (
+	keyActions = []keyAction{
+		{"game.left", "left", []string{"left", "h"}},
+		{"game.right", "right", []string{"right", "l"}},
+		{"game.play", "play card", []string{"enter"}},
+		{"game.play1", "play card 1", []string{"1"}},
+		{"game.play2", "play card 2", []string{"2"}},
+		{"game.play3", "play card 3", []string{"3"}},
+		{"game.play4", "play card 4", []string{"4"}},
+		{"game.play5", "play card 5", []string{"5"}},
+		{"game.swap", "swap life card", []string{"s"}},
+		{"game.confirm", "toggle play confirmation", []string{"C"}},
+		{"game.cancel", "cancel premove", []string{"x"}},
+		{"game.cheat", "cheatsheet", []string{"H"}},
+		{"game.large", "large cards", []string{"L"}},
+		{"game.chat", "toggle chat", []string{"c"}},
+		{"game.talk", "chat", []string{"t"}},
+		{"game.react1", "react 👍", []string{"!"}},
+		{"game.react2", "react 😮", []string{"@"}},
+		{"game.react3", "react nice!", []string{"#"}},
+		{"game.react4", "react oops", []string{"$"}},
+		{"game.mute", "mute reactions", []string{"M"}},
+		{"game.analysis", "replay analysis", []string{"A"}},
+		{"game.openHands", "open hands", []string{"o"}},
+		{"game.coach", "coach", []string{"E"}},
+		{"game.help", "help", []string{"?"}},
+		{"game.quit", "quit", []string{"ctrl+c"}},
+
+		{"lobby.new", "new", []string{"n"}},
+		{"lobby.join", "join game", []string{"j"}},
+		{"lobby.quickPlay", "quick play", []string{"Q"}},
+		{"lobby.tournaments", "tournaments", []string{"t"}},
+		{"lobby.profile", "profile", []string{"p"}},
+		{"lobby.leaderboard", "leaderboards", []string{"L"}},
+		{"lobby.replay", "replay game", []string{"r"}},
+		{"lobby.history", "game history", []string{"y"}},
+		{"lobby.tutorial", "tutorial", []string{"T"}},
+		{"lobby.daily", "daily deal", []string{"D"}},
+		{"lobby.puzzles", "puzzles", []string{"P"}},
+		{"lobby.achievements", "achievements", []string{"A"}},
+		{"lobby.friends", "friends", []string{"F"}},
+		{"lobby.acceptInvite", "accept invite", []string{"a"}},
+		{"lobby.declineInvite", "decline invite", []string{"x"}},
+		{"lobby.choose", "choose", []string{"enter"}},
+		{"lobby.howToPlay", "how to play", []string{"H"}},
+		{"lobby.emoji", "toggle emoji rendering", []string{"E"}},
+		{"lobby.keymap", "keymap", []string{"K"}},
+
+		{"waitingRoom.ready", "ready", []string{"r"}},
+		{"waitingRoom.start", "start", []string{"s"}},
+		{"waitingRoom.changeTeam", "change team", []string{"c"}},
+		{"waitingRoom.leave", "leave", []string{"l"}},
+		{"waitingRoom.spectate", "spectate", []string{"w"}},
+		{"waitingRoom.talk", "chat", []string{"t"}},
+		{"waitingRoom.profile", "player profile", []string{"p"}},
+		{"waitingRoom.invite", "invite friends", []string{"i"}},
+		{"waitingRoom.quit", "quit", []string{"ctrl+c"}},
+
+		{"winScreen.rematch", "propose rematch", []string{"r"}},
+		{"winScreen.accept", "accept rematch", []string{"y"}},
+		{"winScreen.decline", "decline rematch", []string{"n"}},
+
+		{"queue.leave", "leave the queue", []string{"esc"}},
+		{"queue.quit", "quit", []string{"ctrl+c"}},
+
+		{"tournaments.new", "new tournament", []string{"n"}},
+		{"tournaments.open", "open bracket", []string{"enter"}},
+		{"tournaments.back", "back", []string{"esc"}},
+		{"tournaments.quit", "quit", []string{"ctrl+c"}},
+
+		{"bracket.register", "register", []string{"r"}},
+		{"bracket.start", "start", []string{"s"}},
+		{"bracket.play", "play your game", []string{"enter"}},
+		{"bracket.back", "back", []string{"esc"}},
+		{"bracket.quit", "quit", []string{"ctrl+c"}},
+
+		{"profile.back", "back", []string{"esc"}},
+
+		{"leaderboard.nextTab", "next tab", []string{"tab", "right"}},
+		{"leaderboard.prevTab", "previous tab", []string{"shift+tab", "left"}},
+		{"leaderboard.window", "time window", []string{"w"}},
+		{"leaderboard.me", "jump to me", []string{"m"}},
+		{"leaderboard.back", "back", []string{"esc"}},
+		{"leaderboard.quit", "quit", []string{"ctrl+c"}},
+
+		{"history.replay", "replay", []string{"enter"}},
+		{"history.result", "filter result", []string{"tab"}},
+		{"history.players", "filter players", []string{"c"}},
+		{"history.export", "export", []string{"x"}},
+		{"history.back", "back", []string{"esc"}},
+		{"history.quit", "quit", []string{"ctrl+c"}},
+
+		{"daily.play", "play today's deal", []string{"p"}},
+		{"daily.replay", "replay", []string{"enter"}},
+		{"daily.prev", "previous day", []string{"left", "h"}},
+		{"daily.next", "next day", []string{"right", "l"}},
+		{"daily.back", "back", []string{"esc"}},
+		{"daily.quit", "quit", []string{"ctrl+c"}},
+
+		{"puzzles.play", "play", []string{"enter"}},
+		{"puzzles.back", "back", []string{"esc"}},
+		{"puzzles.quit", "quit", []string{"ctrl+c"}},
+
+		{"achievements.back", "back", []string{"esc"}},
+
+		{"friends.invite", "invite", []string{"enter"}},
+		{"friends.add", "add friend", []string{"a"}},
+		{"friends.remove", "remove friend", []string{"x"}},
+		{"friends.back", "back", []string{"esc"}},
+		{"friends.quit", "quit", []string{"ctrl+c"}},
+	}
+
+	// keymapPresets only list the actions that differ from the default.
+	keymapPresets = map[string]map[string][]string{
+		"default": {},
+		"vim": {
+			"game.left":         {"h"},
+			"game.right":        {"l"},
+			"game.play":         {"enter", " "},
+			"game.cheat":        {"K"},
+			"lobby.new":         {"o"},
+			"lobby.join":        {"i"},
+			"waitingRoom.leave": {"q"},
+		},
+		"wasd": {
+			"game.left":  {"a", "left"},
+			"game.right": {"d", "right"},
+			"game.play":  {"w", "enter"},
+			"game.swap":  {"e"},
+		},
+	}
+	keymapPresetNames = []string{"default", "vim", "wasd"}
+
+	// widgetKeys are the keys of the list or table on a screen, it only sees
+	// the keys the screen's actions don't match first. The vim style aliases
+	// are left out, the arrows still do the same.
+	widgetKeys = map[string]screenWidget{
+		"lobby":       {"list", slices.Concat(listWidgetKeys, []string{"/", "q", "esc"})},
+		"waitingRoom": {"list", listWidgetKeys},
+		"tournaments": {"list", slices.Concat(listWidgetKeys, []string{"/"})},
+		"history":     {"list", slices.Concat(listWidgetKeys, []string{"/"})},
+		"puzzles":     {"list", slices.Concat(listWidgetKeys, []string{"/"})},
+		"friends":     {"list", listWidgetKeys},
+		"leaderboard": {"table", tableWidgetKeys},
+		"daily":       {"table", tableWidgetKeys},
+	}
+	listWidgetKeys  = []string{"up", "down", "left", "right", "pgup", "pgdown", "home", "end", "?"}
+	tableWidgetKeys = []string{"up", "down", "pgup", "pgdown", "home", "end"}
+)
+
+type screenWidget struct {
+	name string
+	keys []string
+}
+
+func findKeyAction(id string) keyAction {
+	i := slices.IndexFunc(keyActions, func(a keyAction) bool { return a.id == id })
+	if i == -1 {
+		panic("unknown key action: " + id)
+	}
+	return keyActions[i]
+}
+
+func (km keymapConfig) preset() string {
+	if _, ok := keymapPresets[km.Preset]; !ok {
+		return "default"
+	}
+	return km.Preset
+}
+
+func (km keymapConfig) clone() keymapConfig {
+	km.Overrides = maps.Clone(km.Overrides)
+	if km.Overrides == nil {
+		km.Overrides = map[string][]string{}
+	}
+	return km
+}
+
+// keys resolves an action, overrides first then the preset then the default.
+func (km keymapConfig) keys(id string) []string {
+	if keys, ok := km.Overrides[id]; ok {
+		return keys
+	}
+	if keys, ok := keymapPresets[km.preset()][id]; ok {
+		return keys
+	}
+	return findKeyAction(id).keys
+}
+
+func (km keymapConfig) binding(id string) key.Binding {
+	keys := km.keys(id)
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKeys(keys), findKeyAction(id).help),
+	)
+}
+
+// helpKeys is how the keys are shown in the help views, "←/h".
+func helpKeys(keys []string) string {
+	names := map[string]string{
+		"left":  "←",
+		"right": "→",
+		"up":    "↑",
+		"down":  "↓",
+		" ":     "space",
+	}
+	var shown []string
+	for _, k := range keys {
+		if name, ok := names[k]; ok {
+			k = name
+		}
+		shown = append(shown, k)
+	}
+	return strings.Join(shown, "/")
+}
+
+// conflicts lists every key bound to more than one action of the same screen,
+// or to an action and the screen's widget.
+func (km keymapConfig) conflicts() []string {
+	var found []string
+	bound := map[string]string{} // screen and key to the first action id
+	for screen, widget := range widgetKeys {
+		for _, k := range widget.keys {
+			bound[screen+" "+k] = "the " + widget.name
+		}
+	}
+	for _, action := range keyActions {
+		screen, _, _ := strings.Cut(action.id, ".")
+		for _, k := range km.keys(action.id) {
+			other, ok := bound[screen+" "+k]
+			if !ok {
+				bound[screen+" "+k] = action.id
+				continue
+			}
+			found = append(found, fmt.Sprintf("%q is bound to %s and %s",
+				helpKeys([]string{k}), other, action.id))
+		}
+	}
+	return found
+}
+
+func (m userGlobal) keymapBackend() keymapBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
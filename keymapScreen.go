@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	keymapCursorStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color("69"))
+	keymapConflictStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("9"))
+	keymapColumnStyle = lipgloss.NewStyle().
+				Width(25)
+)
+
+type keymapScreenKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Rebind key.Binding
+	Reset  key.Binding
+	Preset key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+func (k keymapScreenKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Rebind, k.Reset, k.Preset, k.Back}
+}
+
+func (k keymapScreenKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp(), {k.Quit}}
+}
+
+// The keymap screen keys are fixed, so a bad keymap can always be fixed.
+var keymapScreenKeys = keymapScreenKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "move down"),
+	),
+	Rebind: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "rebind"),
+	),
+	Reset: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("backspace", "reset"),
+	),
+	Preset: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "next preset"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "save and go back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+type keymapModel struct {
+	userGlobal userGlobal
+	keymap     keymapConfig
+	cursor     int
+	capturing  bool
+	notice     string
+	keys       keymapScreenKeyMap
+	help       help.Model
+}
+
+func newKeymapModel(userGlobal userGlobal) keymapModel {
+	return keymapModel{
+		userGlobal: userGlobal,
+		keymap:     userGlobal.keymap.clone(),
+		keys:       keymapScreenKeys,
+		help:       help.New(),
+	}
+}
+
+func (m keymapModel) Init() tea.Cmd {
+	return m.userGlobal.LastWindowSizeReplay()
+}
+
+func (m keymapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		m.help.Width = msg.Width
+
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keys.Quit) {
+			return m, tea.Quit
+		}
+		action := keyActions[m.cursor]
+
+		if m.capturing {
+			m.capturing = false
+			if key.Matches(msg, m.keys.Back) {
+				m.notice = ""
+				return m, nil
+			}
+			m.keymap.Overrides[action.id] = []string{msg.String()}
+			m.notice = fmt.Sprintf("%s bound to %s.", action.id, helpKeys([]string{msg.String()}))
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			m.cursor = (m.cursor - 1 + len(keyActions)) % len(keyActions)
+		case key.Matches(msg, m.keys.Down):
+			m.cursor = (m.cursor + 1) % len(keyActions)
+		case key.Matches(msg, m.keys.Rebind):
+			m.capturing = true
+			m.notice = fmt.Sprintf("Press the new key for %s, esc to cancel.", action.id)
+		case key.Matches(msg, m.keys.Reset):
+			delete(m.keymap.Overrides, action.id)
+			m.notice = ""
+		case key.Matches(msg, m.keys.Preset):
+			i := slices.Index(keymapPresetNames, m.keymap.preset())
+			m.keymap.Preset = keymapPresetNames[(i+1)%len(keymapPresetNames)]
+			m.keymap.Overrides = map[string][]string{}
+			m.notice = "Preset " + m.keymap.Preset + ", rebound keys were reset."
+		case key.Matches(msg, m.keys.Back):
+			if len(m.keymap.conflicts()) > 0 {
+				m.notice = "Fix the conflicts before saving."
+				return m, nil
+			}
+			if !m.userGlobal.keymapBackend().saveKeymapRequest(m.keymap) {
+				m.notice = "Could not save the keymap."
+				return m, nil
+			}
+			m.userGlobal.keymap = m.keymap
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		}
+	}
+
+	return m, nil
+}
+
+func (m keymapModel) View() string {
+	conflicts := m.keymap.conflicts()
+	header := []string{
+		titleStyle.Render("Keymap"),
+		"",
+		"Preset: " + m.keymap.preset(),
+		"",
+	}
+	footer := []string{""}
+	for _, conflict := range conflicts {
+		footer = append(footer, keymapConflictStyle.Render(conflict))
+	}
+	footer = append(footer, m.notice, m.help.View(m.keys))
+
+	// Only the rows around the cursor fit on small terminals.
+	rows := max(m.userGlobal.sizeMsg.Height-len(header)-len(footer)-2, 1)
+	first := min(max(m.cursor-rows/2, 0), max(len(keyActions)-rows, 0))
+	var lines []string
+	for i := first; i < min(first+rows, len(keyActions)); i++ {
+		action := keyActions[i]
+		line := lipgloss.JoinHorizontal(lipgloss.Top,
+			keymapColumnStyle.Render(action.id),
+			keymapColumnStyle.Render(action.help),
+			helpKeys(m.keymap.keys(action.id)),
+		)
+		if _, ok := m.keymap.Overrides[action.id]; ok {
+			line += " *"
+		}
+		if i == m.cursor {
+			line = keymapCursorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	s := strings.Join(slices.Concat(header, lines, footer), "\n")
+	return docStyle.Render(s)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOfflineKeymapClone(t *testing.T) {
+	ana := sessions("ana")[0]
+	if km := ana.keymapRequest(); km.Overrides == nil {
+		t.Fatal("a new account's overrides are nil")
+	}
+
+	saved := keymapConfig{Preset: "vim", Overrides: map[string][]string{"game.swap": {"z"}}}
+	ana.saveKeymapRequest(saved)
+	saved.Overrides["game.swap"] = []string{"y"}
+	loaded := ana.keymapRequest()
+	if got := loaded.keys("game.swap"); !slices.Equal(got, []string{"z"}) {
+		t.Errorf("changing the saved keymap changed the store, game.swap is %v", got)
+	}
+
+	loaded.Overrides["game.play"] = []string{"p"}
+	if _, ok := ana.keymapRequest().Overrides["game.play"]; ok {
+		t.Error("changing a loaded keymap changed the store")
+	}
+}
+
+func TestKeymapPresetsHaveNoConflicts(t *testing.T) {
+	for _, preset := range keymapPresetNames {
+		if found := (keymapConfig{Preset: preset}).conflicts(); len(found) > 0 {
+			t.Errorf("the %s preset has conflicts: %v", preset, found)
+		}
+	}
+}
+
+func TestKeymapConflicts(t *testing.T) {
+	tests := []struct {
+		id       string
+		keys     []string
+		conflict bool
+	}{
+		{"lobby.new", []string{"j"}, true},
+		{"lobby.new", []string{"/"}, true},
+		{"lobby.new", []string{"q"}, true},
+		{"history.export", []string{"down"}, true},
+		{"leaderboard.me", []string{"home"}, true},
+		// Friends can't be filtered, the waiting room's list doesn't quit.
+		{"friends.add", []string{"/"}, false},
+		{"waitingRoom.leave", []string{"q"}, false},
+		// Another screen's action.
+		{"game.swap", []string{"n"}, false},
+	}
+	for _, tt := range tests {
+		km := keymapConfig{Overrides: map[string][]string{tt.id: tt.keys}}
+		if found := km.conflicts(); (len(found) > 0) != tt.conflict {
+			t.Errorf("%s on %v: conflicts %v", tt.id, tt.keys, found)
+		}
+	}
+}
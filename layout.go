@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// layoutMode is the arrangement the game screen uses for a window size.
+type layoutMode uint
+
+const (
+	// Below the absolute minimum, only a warning is shown.
+	layoutTooSmall layoutMode = iota
+	// Single column for narrow terminals and phone ssh clients.
+	layoutCompact
+	// The 3x3 box grid.
+	layoutStandard
+	// The 3x3 box grid with a side panel for logs, chat and card tracking.
+	layoutWide
+)
+
+var (
+	windowWidthAbsMin  = 40
+	windowHeightAbsMin = 14
+	windowWidthWide    = 140
+	sidePanelWidth     = 44
+	maxEvents          = 50
+
+	sidePanelStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(inactiveColor)
+	panelTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("69"))
+	tooSmallStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9"))
+)
+
+func pickLayout(width, height int) layoutMode {
+	switch {
+	case width < windowWidthAbsMin || height < windowHeightAbsMin:
+		return layoutTooSmall
+	case width < windowWidthMin || height < windowHighttMin:
+		return layoutCompact
+	case width >= windowWidthWide:
+		return layoutWide
+	default:
+		return layoutStandard
+	}
+}
+
+func (m gsModel) tooSmallView() string {
+	size := m.userGlobal.sizeMsg
+	s := fmt.Sprintf("Terminal too small\n\nNeed at least %dx%d, have %dx%d.\nResize or press ctrl+c to quit.",
+		windowWidthAbsMin, windowHeightAbsMin, size.Width, size.Height)
+	return lipgloss.Place(size.Width, size.Height, lipgloss.Center, lipgloss.Center,
+		tooSmallStyle.Render(s))
+}
+
+// compactView renders the whole game in a single column, each player takes a
+// line and only the cards in play are shown for the table.
+func (m gsModel) compactView() string {
+	width := m.userGlobal.sizeMsg.Width
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.compactHeaderView(),
+		lipgloss.NewStyle().Width(width).Render(m.handView()),
+		lipgloss.NewStyle().Width(width).Render(m.statusBar.View(m.hand)),
+	)
+}
+
+// compactHeaderView is everything above the hand in the compact layout.
+func (m gsModel) compactHeaderView() string {
+	width := m.userGlobal.sizeMsg.Width
+	lines := []string{
+		fmtDeckLine(m.table.deckSize) +
+			m.table.bottomCardStyle.Render(m.table.bottomCard.renderCard(m.userGlobal.renderEmoji)),
+	}
+	for i := range m.gameConfig.MaxPlayers {
+		if i == m.statusBar.mySeat {
+			continue
+		}
+		lines = append(lines, m.compactPlayerLine(i))
+	}
+	inPlay := "In Play:"
+	for i := range m.table.cardsInPlay {
+		inPlay += m.table.cardsInPlay[i].renderCard(m.userGlobal.renderEmoji)
+	}
+	lines = append(lines, inPlay)
+	if m.gameConfig.MaxPlayers > 0 {
+		lines = append(lines, m.compactPlayerLine(m.statusBar.mySeat))
+	}
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func fmtDeckLine(deckSize int) string {
+	return fmt.Sprintf("Deck: %d  Life: ", deckSize)
+}
+
+func (m gsModel) compactPlayerLine(seat int) string {
+	player := m.playerSeats[seat]
+	marker := " "
+	if m.statusBar.turn == seat {
+		marker = ">"
+	}
+	afk := ""
+	if player.afk {
+		afk = " (afk)"
+	}
+	return fmt.Sprintf("%s %s Score: %d Cards: %d%s %s", marker, player.name, player.score, player.handSize, afk, player.reaction)
+}
+
+// sidePanelView stacks the event log, the chat and the cards seen so far.
+func (m gsModel) sidePanelView() string {
+	const chatHeight = 8
+	width := m.sidePanel.GetWidth()
+	height := m.sidePanel.GetHeight()
+
+	tracker := m.cardTrackerView()
+	chat := lipgloss.JoinVertical(lipgloss.Left,
+		panelTitleStyle.Render("Chat:"),
+		m.chat.View(width, chatHeight-1),
+	)
+	logHeight := max(height-lipgloss.Height(tracker)-chatHeight-3, 1) // Title and blank lines
+	events := m.events[max(len(m.events)-logHeight, 0):]
+	log := lipgloss.NewStyle().Width(width).MaxHeight(logHeight).
+		Render(strings.Join(events, "\n"))
+	log = lipgloss.PlaceVertical(logHeight, lipgloss.Bottom, log)
+
+	s := lipgloss.JoinVertical(lipgloss.Left,
+		panelTitleStyle.Render("Log:"),
+		log,
+		"",
+		chat,
+		"",
+		tracker,
+	)
+	return m.sidePanel.Render(s)
+}
+
+// seenCards are all the cards that have been played this game.
+func (m gsModel) seenCards() []card {
+	seen := slices.Clone(m.table.cardsInPlay)
+	for i := range m.gameConfig.MaxPlayers {
+		seen = append(seen, m.playerSeats[i].scorePile...)
+	}
+	return seen
+}
+
+func (m gsModel) cardTrackerView() string {
+	seen := m.seenCards()
+	slices.SortFunc(seen, func(a, b card) int {
+		return b.val - a.val
+	})
+	lines := []string{panelTitleStyle.Render("Cards seen:")}
+	for _, suit := range SUITS {
+		label := newCard(suit + ":1")
+		line := label.charSuit + ":"
+		if m.userGlobal.renderEmoji {
+			line = label.emojiSuit + ":"
+		}
+		for _, c := range seen {
+			if c.suitString == suit {
+				line += fmt.Sprintf("%3d", c.num)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// logEvent keeps the last maxEvents game events for the side panel.
+func (m *gsModel) logEvent(format string, a ...any) {
+	m.events = append(m.events, fmt.Sprintf(format, a...))
+	if len(m.events) > maxEvents {
+		m.events = m.events[len(m.events)-maxEvents:]
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// Boards are the same for everyone, they are cached across sessions.
+	LEADERBOARD_TTL = time.Second * 30
+
+	leaderboardTabs = []leaderboardTab{
+		{"1v1", 2},
+		{"3 players", 3},
+		{"Teams", 4},
+	}
+	leaderboardWindows = []string{"weekly", "monthly", "all-time"}
+
+	activeTabStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFDF5")).
+			Background(lipgloss.Color("#25A065")).
+			Padding(0, 1)
+	inactiveTabStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("241")).
+				Padding(0, 1)
+)
+
+type leaderboardTab struct {
+	name        string
+	playerCount int
+}
+
+// leaderboardQuery picks a board, Window is weekly, monthly or all-time.
+type leaderboardQuery struct {
+	PlayerCount int    `json:"playerCount"`
+	Window      string `json:"window"`
+}
+
+type leaderboardEntry struct {
+	Rank     int    `json:"rank"`
+	Username string `json:"username"`
+	Rating   int    `json:"rating"`
+	Wins     int    `json:"wins"`
+	Played   int    `json:"played"`
+}
+
+type leaderboard struct {
+	Entries []leaderboardEntry `json:"entries"`
+	// The player's own entry, even when not in the top entries.
+	Me *leaderboardEntry `json:"me"`
+}
+
+// leaderboardBackend serves the boards, the game server or the offline
+// stand-in.
+type leaderboardBackend interface {
+	leaderboardRequest(query leaderboardQuery) leaderboard
+}
+
+func (m userGlobal) leaderboardBackend() leaderboardBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
+
+type cachedLeaderboard struct {
+	board   leaderboard
+	fetched time.Time
+}
+
+// leaderboardCache keeps boards for LEADERBOARD_TTL, the key includes the
+// username because of the Me entry.
+var leaderboardCache = struct {
+	mu     sync.Mutex
+	boards map[string]cachedLeaderboard
+}{boards: map[string]cachedLeaderboard{}}
+
+func fetchLeaderboard(backend leaderboardBackend, username string, query leaderboardQuery) leaderboard {
+	cacheKey := fmt.Sprintf("%d/%s/%s", query.PlayerCount, query.Window, username)
+
+	leaderboardCache.mu.Lock()
+	cached, ok := leaderboardCache.boards[cacheKey]
+	leaderboardCache.mu.Unlock()
+	if ok && time.Since(cached.fetched) < LEADERBOARD_TTL {
+		return cached.board
+	}
+
+	board := backend.leaderboardRequest(query)
+
+	leaderboardCache.mu.Lock()
+	leaderboardCache.boards[cacheKey] = cachedLeaderboard{board, time.Now()}
+	leaderboardCache.mu.Unlock()
+	return board
+}
+
+// rankResults ranks by wins, then by fewer games played.
+func rankResults(entries map[string]leaderboardEntry) []leaderboardEntry {
+	ranked := slices.Collect(maps.Values(entries))
+	slices.SortFunc(ranked, func(a, b leaderboardEntry) int {
+		return cmp.Or(b.Wins-a.Wins, a.Played-b.Played, strings.Compare(a.Username, b.Username))
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	return ranked
+}
+
+func windowStart(window string, now time.Time) time.Time {
+	switch window {
+	case "weekly":
+		return now.AddDate(0, 0, -7)
+	case "monthly":
+		return now.AddDate(0, -1, 0)
+	}
+	return time.Time{}
+}
+
+type leaderboardKeyMap struct {
+	NextTab key.Binding
+	PrevTab key.Binding
+	Window  key.Binding
+	Me      key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+func (k leaderboardKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.NextTab, k.Window, k.Me, k.Back}
+}
+
+func (k leaderboardKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.NextTab, k.PrevTab, k.Window, k.Me, k.Back, k.Quit}}
+}
+
+func newLeaderboardKeyMap(km keymapConfig) leaderboardKeyMap {
+	return leaderboardKeyMap{
+		NextTab: km.binding("leaderboard.nextTab"),
+		PrevTab: km.binding("leaderboard.prevTab"),
+		Window:  km.binding("leaderboard.window"),
+		Me:      km.binding("leaderboard.me"),
+		Back:    km.binding("leaderboard.back"),
+		Quit:    km.binding("leaderboard.quit"),
+	}
+}
+
+type leaderboardMsg struct {
+	query leaderboardQuery
+	board leaderboard
+}
+
+type leaderboardModel struct {
+	tab        int
+	window     int
+	board      leaderboard
+	table      table.Model
+	keys       leaderboardKeyMap
+	help       help.Model
+	userGlobal userGlobal
+}
+
+func newLeaderboard(userGlobal userGlobal) leaderboardModel {
+	return leaderboardModel{
+		table: table.New(
+			table.WithColumns([]table.Column{
+				{Title: "#", Width: 5},
+				{Title: "Player", Width: 20},
+				{Title: "Rating", Width: 8},
+				{Title: "Wins", Width: 6},
+				{Title: "Played", Width: 6},
+			}),
+			table.WithFocused(true),
+		),
+		keys:       newLeaderboardKeyMap(userGlobal.keymap),
+		help:       help.New(),
+		userGlobal: userGlobal,
+	}
+}
+
+func (m leaderboardModel) query() leaderboardQuery {
+	return leaderboardQuery{
+		PlayerCount: leaderboardTabs[m.tab].playerCount,
+		Window:      leaderboardWindows[m.window],
+	}
+}
+
+func (m leaderboardModel) fetch() tea.Cmd {
+	query := m.query()
+	return func() tea.Msg {
+		board := fetchLeaderboard(m.userGlobal.leaderboardBackend(), m.userGlobal.username, query)
+		return leaderboardMsg{query, board}
+	}
+}
+
+func (m leaderboardModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), m.fetch())
+}
+
+func (m leaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		m.help.Width = msg.Width
+		_, v := docStyle.GetFrameSize()
+		m.table.SetHeight(max(msg.Height-v-6, 3)) // Title, tabs, me and help
+
+	case leaderboardMsg:
+		if msg.query != m.query() {
+			return m, nil // A tab that was left already
+		}
+		m.board = msg.board
+		var rows []table.Row
+		for _, entry := range m.board.Entries {
+			rows = append(rows, table.Row{
+				fmt.Sprint(entry.Rank),
+				entry.Username,
+				m.rating(entry),
+				fmt.Sprint(entry.Wins),
+				fmt.Sprint(entry.Played),
+			})
+		}
+		m.table.SetRows(rows)
+		m.table.SetCursor(0)
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		case key.Matches(msg, m.keys.NextTab):
+			m.tab = (m.tab + 1) % len(leaderboardTabs)
+			return m, m.fetch()
+		case key.Matches(msg, m.keys.PrevTab):
+			m.tab = (m.tab - 1 + len(leaderboardTabs)) % len(leaderboardTabs)
+			return m, m.fetch()
+		case key.Matches(msg, m.keys.Window):
+			m.window = (m.window + 1) % len(leaderboardWindows)
+			return m, m.fetch()
+		case key.Matches(msg, m.keys.Me):
+			i := slices.IndexFunc(m.board.Entries, func(e leaderboardEntry) bool {
+				return e.Username == m.userGlobal.username
+			})
+			if i != -1 {
+				m.table.SetCursor(i)
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// rating is not shown for three player games, they are not rated.
+func (m leaderboardModel) rating(entry leaderboardEntry) string {
+	if leaderboardTabs[m.tab].playerCount == 3 {
+		return "-"
+	}
+	return fmt.Sprint(entry.Rating)
+}
+
+func (m leaderboardModel) View() string {
+	var tabs []string
+	for i, tab := range leaderboardTabs {
+		if i == m.tab {
+			tabs = append(tabs, activeTabStyle.Render(tab.name))
+		} else {
+			tabs = append(tabs, inactiveTabStyle.Render(tab.name))
+		}
+	}
+	header := lipgloss.JoinHorizontal(lipgloss.Top, tabs...) +
+		inactiveTabStyle.Render("window: "+leaderboardWindows[m.window])
+
+	me := "You have no games in this window."
+	if m.board.Me != nil {
+		me = fmt.Sprintf("You: #%d, %d wins in %d games", m.board.Me.Rank, m.board.Me.Wins, m.board.Me.Played)
+	}
+
+	s := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Leaderboards"),
+		header,
+		m.table.View(),
+		me,
+		m.help.View(m.keys),
+	)
+	return docStyle.Render(s)
+}
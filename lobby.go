@@ -29,40 +29,48 @@ var (
 )
 
 type listKeyMap struct {
-	insertItem key.Binding
-	joinGame   key.Binding
-	replayGame key.Binding
-	choose     key.Binding
-	help       key.Binding
-	emoji      key.Binding
+	insertItem    key.Binding
+	joinGame      key.Binding
+	quickPlay     key.Binding
+	tournaments   key.Binding
+	profile       key.Binding
+	leaderboard   key.Binding
+	history       key.Binding
+	tutorial      key.Binding
+	daily         key.Binding
+	puzzles       key.Binding
+	achievements  key.Binding
+	friends       key.Binding
+	acceptInvite  key.Binding
+	declineInvite key.Binding
+	replayGame    key.Binding
+	choose        key.Binding
+	help          key.Binding
+	emoji         key.Binding
+	keymap        key.Binding
 }
 
-func newListKeyMap() *listKeyMap {
+func newListKeyMap(km keymapConfig) *listKeyMap {
 	return &listKeyMap{
-		insertItem: key.NewBinding(
-			key.WithKeys("n"),
-			key.WithHelp("n", "new"),
-		),
-		joinGame: key.NewBinding(
-			key.WithKeys("j"),
-			key.WithHelp("j", "join game"),
-		),
-		replayGame: key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "replay game"),
-		),
-		choose: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "choose"),
-		),
-		help: key.NewBinding(
-			key.WithKeys("H"),
-			key.WithHelp("H", "how to play"),
-		),
-		emoji: key.NewBinding(
-			key.WithKeys("E"),
-			key.WithHelp("E", "toggle emoji rendering"),
-		),
+		insertItem:    km.binding("lobby.new"),
+		joinGame:      km.binding("lobby.join"),
+		quickPlay:     km.binding("lobby.quickPlay"),
+		tournaments:   km.binding("lobby.tournaments"),
+		profile:       km.binding("lobby.profile"),
+		leaderboard:   km.binding("lobby.leaderboard"),
+		history:       km.binding("lobby.history"),
+		tutorial:      km.binding("lobby.tutorial"),
+		daily:         km.binding("lobby.daily"),
+		puzzles:       km.binding("lobby.puzzles"),
+		achievements:  km.binding("lobby.achievements"),
+		friends:       km.binding("lobby.friends"),
+		acceptInvite:  km.binding("lobby.acceptInvite"),
+		declineInvite: km.binding("lobby.declineInvite"),
+		replayGame:    km.binding("lobby.replay"),
+		choose:        km.binding("lobby.choose"),
+		help:          km.binding("lobby.howToPlay"),
+		emoji:         km.binding("lobby.emoji"),
+		keymap:        km.binding("lobby.keymap"),
 	}
 }
 
@@ -74,6 +82,9 @@ type lobbyModel struct {
 	userGlobal   userGlobal
 	fullHelp     MarkdownModel
 	showFH       bool
+	// Invites to friends' games, the oldest is shown above the list.
+	invites        []invite
+	lastInvitePoll time.Time
 }
 
 type itemsMsg struct {
@@ -83,7 +94,7 @@ type itemsMsg struct {
 func newLobby(userGlobal userGlobal) lobbyModel {
 	var (
 		delegateKeys = newDelegateKeyMap()
-		listKeys     = newListKeyMap()
+		listKeys     = newListKeyMap(userGlobal.keymap)
 	)
 
 	// Make initial list of items
@@ -104,22 +115,40 @@ func newLobby(userGlobal userGlobal) lobbyModel {
 		return []key.Binding{
 			listKeys.insertItem,
 			listKeys.joinGame,
+			listKeys.quickPlay,
+			listKeys.tournaments,
+			listKeys.profile,
+			listKeys.leaderboard,
 			listKeys.replayGame,
+			listKeys.history,
+			listKeys.tutorial,
+			listKeys.daily,
+			listKeys.puzzles,
+			listKeys.achievements,
+			listKeys.friends,
+			listKeys.acceptInvite,
+			listKeys.declineInvite,
 			listKeys.choose,
 			listKeys.help,
 			listKeys.emoji,
+			listKeys.keymap,
 		}
 	}
 	gamesList.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			listKeys.insertItem,
 			listKeys.joinGame,
+			listKeys.quickPlay,
+			listKeys.acceptInvite,
+			listKeys.declineInvite,
 			listKeys.choose,
 			listKeys.help,
 		}
 	}
 	gamesList.KeyMap.Filter.SetHelp("/", "search")
 
+	listKeys.acceptInvite.SetEnabled(false)
+	listKeys.declineInvite.SetEnabled(false)
 	lm.list = gamesList
 	lm.keys = listKeys
 	lm.delegateKeys = delegateKeys
@@ -140,7 +169,7 @@ func doTick() tea.Cmd {
 
 func (lm lobbyModel) Init() tea.Cmd {
 	_, cmd := lm.updateIfStale(0)
-	return tea.Batch(cmd, doTick(), lm.userGlobal.LastWindowSizeReplay(), lm.list.StartSpinner())
+	return tea.Batch(cmd, doTick(), lm.userGlobal.LastWindowSizeReplay(), lm.list.StartSpinner(), lm.pollInvites())
 }
 
 func (m lobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -150,14 +179,24 @@ func (m lobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case joinGameMsg:
-		wrm := newWaitingRoom(m.userGlobal)
-		wrm.list.Title = "GameID: " + msg.gameId.GameId
+		wrm := newWaitingRoom(m.userGlobal, msg.gameId)
 		cmd = wrm.Init()
 		return wrm, cmd
 
 	case tickMsg:
 		m, cmd = m.updateIfStale(lobbyIsStale)
 		cmds = append(cmds, cmd, doTick())
+		if time.Since(m.lastInvitePoll) >= INVITE_POLL_INTERVAL {
+			m.lastInvitePoll = time.Now()
+			cmds = append(cmds, m.pollInvites())
+		}
+
+	case invitesMsg:
+		m.setInvites(msg)
+
+	case inviteFailedMsg:
+		m.list.StatusMessageLifetime = time.Second * 2
+		cmds = append(cmds, m.list.NewStatusMessage("The game is gone."))
 
 	case itemsMsg:
 		cmd = m.list.SetItems(msg.items)
@@ -168,8 +207,7 @@ func (m lobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.WindowSizeMsg:
 		m.userGlobal.sizeMsg = msg
-		h, v := docStyle.GetFrameSize()
-		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.resize()
 		var model tea.Model
 		model, cmd = m.fullHelp.Update(msg)
 		if fullHelp, ok := model.(MarkdownModel); ok {
@@ -209,9 +247,47 @@ func (m lobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.joinGame):
 			jg := newJoinGame(m, m.userGlobal)
 			return jg, jg.Init()
+		case key.Matches(msg, m.keys.quickPlay):
+			qp := newQuickPlay(m, m.userGlobal)
+			return qp, qp.Init()
+		case key.Matches(msg, m.keys.tournaments):
+			tm := newTournaments(m.userGlobal)
+			return tm, tm.Init()
+		case key.Matches(msg, m.keys.profile):
+			pm := newProfile(m, m.userGlobal, m.userGlobal.username)
+			return pm, pm.Init()
+		case key.Matches(msg, m.keys.leaderboard):
+			lb := newLeaderboard(m.userGlobal)
+			return lb, lb.Init()
 		case key.Matches(msg, m.keys.replayGame):
 			rg := newReplayGame(m, m.userGlobal)
 			return rg, rg.Init()
+		case key.Matches(msg, m.keys.tutorial):
+			tm := newTutorialGSModel(m.userGlobal)
+			return tm, tm.Init()
+		case key.Matches(msg, m.keys.daily):
+			dm := newDaily(m.userGlobal)
+			return dm, dm.Init()
+		case key.Matches(msg, m.keys.puzzles):
+			pm := newPuzzles(m.userGlobal)
+			return pm, pm.Init()
+		case key.Matches(msg, m.keys.achievements):
+			am := newAchievements(m.userGlobal)
+			return am, am.Init()
+		case key.Matches(msg, m.keys.friends):
+			fm := newFriends(m, m.userGlobal, gameId{})
+			return fm, fm.Init()
+		case key.Matches(msg, m.keys.acceptInvite), key.Matches(msg, m.keys.declineInvite):
+			accept := key.Matches(msg, m.keys.acceptInvite)
+			cmd = m.answerInvite(m.invites[0], accept)
+			m.setInvites(m.invites[1:])
+			return m, cmd
+		case key.Matches(msg, m.keys.history):
+			hm := newHistory(m.userGlobal)
+			return hm, hm.Init()
+		case key.Matches(msg, m.keys.keymap):
+			km := newKeymapModel(m.userGlobal)
+			return km, km.Init()
 		case key.Matches(msg, m.keys.emoji):
 			if m.userGlobal.renderEmoji {
 				m.list.StatusMessageLifetime = time.Second * 2
@@ -237,6 +313,9 @@ func (lm lobbyModel) View() string {
 	if lm.showFH {
 		return lm.fullHelp.View()
 	}
+	if len(lm.invites) > 0 {
+		return docStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lm.inviteView(), lm.list.View()))
+	}
 	return docStyle.Render(lm.list.View())
 }
 
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"slices"
+	"sync"
+)
+
+const localSeat = 0
+
+// localEngine is a two player game against a bot played in the ssh server, it
+// answers the game screen's requests instead of the game server. The tutorial
+// and the daily deal run on it.
+type localEngine struct {
+	mu        sync.Mutex
+	username  string
+	swapRules []swapRule
+	state     gameState
+	life      card
+	log       []action
+	sent      int
+	// The tutorial's prompts, the player has to follow them.
+	steps []tutorialStep
+	step  int
+	// Why the last move was refused.
+	blocked string
+	// The bot's first cards, then its strategy, the playout policy by default.
+	botCards    []string
+	botStrategy func(gameState) int
+	botPlays    int
+	points      [2]int
+	over        bool
+}
+
+// newLocalEngine deals the hands, the deck's last card is the life card. The
+// player leads.
+func newLocalEngine(config gameConfigPayload, username, bot string, hands [2][]card, deck []card) *localEngine {
+	life := deck[len(deck)-1]
+	return newPositionEngine(config, username, bot, gameState{
+		players:  2,
+		lifeSuit: life.suitString,
+		hands:    [][]card{hands[0], hands[1]},
+		deck:     deck,
+		leader:   localSeat,
+	}, life)
+}
+
+// newPositionEngine starts from a position, the life card is still shown
+// once the deck is empty.
+func newPositionEngine(config gameConfigPayload, username, bot string, state gameState, life card) *localEngine {
+	e := &localEngine{
+		username:  username,
+		swapRules: config.swapRules(),
+		state:     state,
+		life:      life,
+	}
+	e.add("GAME_CONFIG", config)
+	e.add("GAME_STARTED", gameStartedPayload{
+		Seats:        []seat{{localSeat, username}, {1, bot}},
+		StartingSeat: state.leader,
+	})
+	e.add("BOTTOM_CARD_SELECTED", bottomCardSelectedPayload{BottomCard: e.life.cardString(), bottomCard: e.life})
+	e.add("GRACE_PERIOD_ENDED", gracePeriodEndedPayload{})
+	return e
+}
+
+func (e *localEngine) add(actionType string, payload Payload) {
+	e.log = append(e.log, action{Type: actionType, Payload: payload})
+}
+
+// currentStep is nil once the steps are done.
+func (e *localEngine) currentStep() *tutorialStep {
+	if e.step >= len(e.steps) {
+		return nil
+	}
+	return &e.steps[e.step]
+}
+
+// play logs the card, the trick, the draws and the end of the game.
+func (e *localEngine) play(index int) {
+	seat := e.state.turn()
+	c := e.state.hands[seat][index]
+	e.add("CARD_PLAYED", cardPlayedPayload{Seat: seat, Index: index, Card: c.cardString(), card: c})
+
+	deckSize := len(e.state.deck)
+	winner, points, done := e.state.play(index)
+	if !done {
+		return
+	}
+	e.points[winner] += points
+	e.add("TURN_WON", turnWonPayload{Seat: winner})
+	for i := range deckSize - len(e.state.deck) {
+		e.add("CARD_DRAWN", cardDrawnPayload{Seat: (winner + i) % e.state.players})
+	}
+	if len(e.state.hands[e.state.turn()]) == 0 {
+		e.over = true
+		won := gameWonPayload{Seat: -1}
+		switch {
+		case e.points[0] > e.points[1]:
+			won.Seat = 0
+		case e.points[1] > e.points[0]:
+			won.Seat = 1
+		}
+		e.add("GAME_WON", won)
+	}
+}
+
+// botMove is scripted for the first tricks, the strategy afterwards.
+func (e *localEngine) botMove() int {
+	defer func() { e.botPlays++ }()
+	if e.botPlays < len(e.botCards) {
+		i := slices.IndexFunc(e.state.hands[1], func(c card) bool {
+			return c.cardString() == e.botCards[e.botPlays]
+		})
+		if i != -1 {
+			return i
+		}
+	}
+	if e.botStrategy != nil {
+		return e.botStrategy(e.state)
+	}
+	return e.state.policy()
+}
+
+func (e *localEngine) actionsRequest() []action {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for !e.over && e.state.turn() != localSeat {
+		e.play(e.botMove())
+	}
+	actions := e.log[e.sent:]
+	e.sent = len(e.log)
+	return actions
+}
+
+func (e *localEngine) mySeatRequest() mySeat {
+	return mySeat{Seat: localSeat}
+}
+
+func (e *localEngine) handRequest() []card {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.state.hands[localSeat])
+}
+
+func (e *localEngine) playCardRequest(index handIndex) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	hand := e.state.hands[localSeat]
+	if e.over || e.state.turn() != localSeat || index.Index < 0 || index.Index >= len(hand) {
+		return false
+	}
+	step := e.currentStep()
+	if played := hand[index.Index].cardString(); step != nil && (step.swap || played != step.card && step.card != "") {
+		e.blocked = step.refusals[played]
+		if e.blocked == "" {
+			e.blocked = step.refusals[""]
+		}
+		if e.blocked == "" {
+			e.blocked = "Not that one, follow the prompt."
+		}
+		return false
+	}
+	e.blocked = ""
+	if e.currentStep() != nil {
+		e.step++
+	}
+	e.play(index.Index)
+	return true
+}
+
+func (e *localEngine) swapBottomCardRequest(swap swapBottomCard) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	hand := e.state.hands[localSeat]
+	i := slices.IndexFunc(hand, func(c card) bool { return c.cardString() == swap.Card })
+	if e.over || e.state.turn() != localSeat || i == -1 || len(e.state.deck) <= 1 {
+		return false
+	}
+	candidate, ok := swapCandidate(e.swapRules, e.life, hand)
+	if !ok || candidate.cardString() != swap.Card {
+		return false
+	}
+	last := len(e.state.deck) - 1
+	hand[i], e.state.deck[last] = e.state.deck[last], hand[i]
+	e.life = e.state.deck[last]
+	e.add("SWAP_BOTTOM_CARD", swapBottomCardPayload{Card: swap.Card})
+	e.blocked = ""
+	if step := e.currentStep(); step != nil && step.swap {
+		e.step++
+	}
+	return true
+}
+
+func (e *localEngine) leaveGameRequest() bool {
+	return true
+}
+
+func (e *localEngine) reactRequest(r react) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.add("REACTION", reactionPayload{Seat: localSeat, Reaction: r.Reaction})
+	return true
+}
+
+// actions is the whole game so far, for the daily board.
+func (e *localEngine) actions() []action {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.log)
+}
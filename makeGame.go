@@ -40,10 +40,20 @@ func newMakeGame(nv tea.Model, userGlobal userGlobal) makeGameModel {
 					Options(huh.NewOptions(2, 3, 4)...).
 					Title("Max Players:"),
 
-				huh.NewSelect[bool]().
-					Key("swapBottomCard").
-					Options(huh.NewOptions(true, false)...).
-					Title("Enable Swap Life Card house rule:"),
+				huh.NewSelect[string]().
+					Key("variant").
+					Options(variantOptions()...).
+					Title("Variant:"),
+
+				huh.NewSelect[string]().
+					Key("match").
+					Options(matchPresetOptions()...).
+					Title("Match:"),
+
+				huh.NewSelect[string]().
+					Key("swapRules").
+					Options(swapPresetOptions()...).
+					Title("Swap Life Card house rule:"),
 
 				huh.NewConfirm().
 					Title("Are you sure?").
@@ -76,10 +86,14 @@ func (m makeGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.nextView, m.nextView.Init()
 		}
 
+		swapRules := findSwapPreset(m.form.GetString("swapRules")).rules
 		gc := gameConfig{
 			GameType:       m.form.GetString("gameType"),
 			MaxPlayers:     m.form.GetInt("maxPlayers"),
-			SwapBottomCard: m.form.GetBool("swapBottomCard"),
+			SwapBottomCard: len(swapRules) > 0,
+			SwapRules:      swapRules,
+			Variant:        m.form.GetString("variant"),
+			Match:          findMatchPreset(m.form.GetString("match")),
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second/2)
@@ -97,8 +111,7 @@ func (m makeGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			log.Fatal(err)
 		}
 
-		wrm := newWaitingRoom(m.userGlobal)
-		wrm.list.Title = "GameID: " + game.GameId
+		wrm := newWaitingRoom(m.userGlobal, gameId{GameId: game.GameId})
 		cmd = wrm.Init()
 		return wrm, cmd
 	}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	MATCH_POLL_INTERVAL = time.Second
+	MATCH_NEXT_GAME     = time.Second * 10
+
+	matchScoreStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("69"))
+)
+
+const (
+	matchBestOf = "bestOf"
+	matchRaceTo = "raceTo"
+)
+
+type matchPreset struct {
+	name  string
+	match *matchConfig
+}
+
+var matchPresets = []matchPreset{
+	{"single game", nil},
+	{"best of 3", &matchConfig{Mode: matchBestOf, Target: 3}},
+	{"best of 5", &matchConfig{Mode: matchBestOf, Target: 5}},
+	{"first to 3 wins", &matchConfig{Mode: matchRaceTo, Target: 3}},
+	{"first to 5 wins", &matchConfig{Mode: matchRaceTo, Target: 5}},
+}
+
+func matchPresetOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, p := range matchPresets {
+		options = append(options, huh.NewOption(p.name, p.name))
+	}
+	return options
+}
+
+func findMatchPreset(name string) *matchConfig {
+	i := slices.IndexFunc(matchPresets, func(p matchPreset) bool { return p.name == name })
+	if i == -1 {
+		return nil
+	}
+	return matchPresets[i].match
+}
+
+func (mc matchConfig) String() string {
+	if mc.Mode == matchRaceTo {
+		return fmt.Sprintf("first to %d wins", mc.Target)
+	}
+	return fmt.Sprintf("best of %d", mc.Target)
+}
+
+// scoreLine is the running score, most wins first.
+func (ms matchStatus) scoreLine() string {
+	names := slices.Collect(maps.Keys(ms.Wins))
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(ms.Wins[b]-ms.Wins[a], strings.Compare(a, b))
+	})
+	var scores []string
+	for _, name := range names {
+		scores = append(scores, fmt.Sprintf("%s %d", name, ms.Wins[name]))
+	}
+	return strings.Join(scores, " - ")
+}
+
+type matchStatusMsg matchStatus
+
+type matchCountdownMsg struct{}
+
+// pollMatch asks for the match until the server has made the next game or
+// the match is over.
+func (m winScreen) pollMatch(wait time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(wait)
+		return matchStatusMsg(m.userGlobal.rh.matchRequest(gameId{GameId: m.gameConfig.GameId}))
+	}
+}
+
+func matchCountdown() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return matchCountdownMsg{}
+	})
+}
+
+// nextMatchGame goes to the match's next game, the server already seated
+// everyone in it.
+func (m winScreen) nextMatchGame() (tea.Model, tea.Cmd) {
+	wrm := newWaitingRoom(m.userGlobal, gameId{GameId: m.match.NextGameId})
+	return wrm, wrm.Init()
+}
+
+// leaveMatch gives up the seat the server kept us in the next game, the
+// others would wait for us otherwise.
+func (m winScreen) leaveMatch() (tea.Model, tea.Cmd) {
+	rh := m.userGlobal.rh
+	lm := newLobby(m.userGlobal)
+	return lm, tea.Batch(lm.Init(), func() tea.Msg {
+		rh.leaveGameRequest()
+		return nil
+	})
+}
+
+// matchView goes under the score counters while a match is being played.
+func (m winScreen) matchView() string {
+	title := "Match, " + m.gameConfig.Match.String()
+	if m.match.MatchId == "" {
+		return title + "\nWaiting for the match score..."
+	}
+	lines := []string{
+		title + ", game " + fmt.Sprint(len(m.match.Games)),
+		matchScoreStyle.Render(m.match.scoreLine()),
+	}
+	switch {
+	case m.match.Over:
+		lines = append(lines, m.match.Winner+" won the match!")
+	case m.match.NextGameId != "":
+		lines = append(lines, fmt.Sprintf("Next game in %s, esc to leave the match", m.nextGameIn))
+	default:
+		lines = append(lines, "Waiting for the next game...")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// matchSummary is the last screen of a match, every game and the final score.
+type matchSummary struct {
+	match      matchStatus
+	config     matchConfig
+	style      lipgloss.Style
+	userGlobal userGlobal
+}
+
+func newMatchSummary(match matchStatus, config matchConfig, userGlobal userGlobal) matchSummary {
+	return matchSummary{
+		match:      match,
+		config:     config,
+		style:      winScreenStyle,
+		userGlobal: userGlobal,
+	}
+}
+
+func (m matchSummary) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), debounce())
+}
+
+func (m matchSummary) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		m.style = m.style.
+			Width(max(windowWidthMin, msg.Width) - 2).
+			Height(max(windowHighttMin, msg.Height) - 2)
+	case tea.KeyMsg:
+		lm := newLobby(m.userGlobal)
+		return lm, lm.Init()
+	}
+	return m, nil
+}
+
+func (m matchSummary) View() string {
+	games := []string{}
+	for i, game := range m.match.Games {
+		winner := game.Winner
+		if winner == "" {
+			winner = "tie"
+		}
+		games = append(games, fmt.Sprintf("Game %d: %s", i+1, winner))
+	}
+	s := lipgloss.JoinVertical(lipgloss.Center,
+		winnerStyle.Render(m.match.Winner+" won the match!"),
+		"Match, "+m.config.String(),
+		"",
+		strings.Join(games, "\n"),
+		"",
+		matchScoreStyle.Render(m.match.scoreLine()),
+		helpStyle.AlignHorizontal(lipgloss.Center).Render("Press any key to exit"),
+	)
+	return m.style.Render(s)
+}
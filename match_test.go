@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchScoreLine(t *testing.T) {
+	ms := matchStatus{Wins: map[string]int{"cy": 1, "bo": 2, "ana": 1}}
+	// Most wins first, ties by name.
+	if got, want := ms.scoreLine(), "bo 2 - ana 1 - cy 1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := (matchStatus{}).scoreLine(); got != "" {
+		t.Errorf("no games got %q", got)
+	}
+}
+
+func TestMatchPresets(t *testing.T) {
+	if findMatchPreset("single game") != nil || findMatchPreset("unknown") != nil {
+		t.Error("a single game is a match")
+	}
+	for _, p := range matchPresets[1:] {
+		mc := findMatchPreset(p.name)
+		if mc == nil || mc.String() != p.name {
+			t.Errorf("%q is %v", p.name, mc)
+		}
+	}
+}
+
+func TestMatchView(t *testing.T) {
+	m := winScreen{gameConfig: gameConfigPayload{Match: &matchConfig{Mode: matchRaceTo, Target: 3}}}
+	if got := m.matchView(); !strings.Contains(got, "Waiting for the match score") {
+		t.Errorf("before the first status got %q", got)
+	}
+
+	m.match = matchStatus{
+		MatchId: "m1",
+		Games:   []matchGame{{GameId: "g1", Winner: "ana"}, {GameId: "g2"}},
+		Wins:    map[string]int{"ana": 1, "bo": 0},
+	}
+	got := m.matchView()
+	for _, want := range []string{"first to 3 wins, game 2", "ana 1 - bo 0", "Waiting for the next game"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q is missing %q", got, want)
+		}
+	}
+
+	m.match.Over, m.match.Winner = true, "ana"
+	if got := m.matchView(); !strings.Contains(got, "ana won the match!") {
+		t.Errorf("once over got %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	DOUBLE_CLICK_TIME = time.Millisecond * 400
+)
+
+// clickZone is a rectangle of the game screen, ends are exclusive.
+type clickZone struct {
+	x0, y0 int
+	x1, y1 int
+}
+
+func (z clickZone) contains(x, y int) bool {
+	return x >= z.x0 && x < z.x1 && y >= z.y0 && y < z.y1
+}
+
+type lastClick struct {
+	card int
+	time time.Time
+}
+
+// handZones mirrors handView, one zone per card in the hand.
+func (m gsModel) handZones() []clickZone {
+	var top int
+	switch m.layout {
+	case layoutCompact:
+		top = lipgloss.Height(m.compactHeaderView())
+	case layoutStandard, layoutWide:
+		top = lipgloss.Height(m.gridRowsView())
+	default:
+		return nil
+	}
+
+	x := lipgloss.Width("Hand:")
+	var zones []clickZone
+	for i := range m.hand {
+		if m.largeCards {
+			zones = append(zones, clickZone{x, top, x + largeCardWidth, top + largeHandHeight})
+			x += largeCardWidth
+			continue
+		}
+		x += lipgloss.Width(" 1:")
+		width := lipgloss.Width(m.renderHandCard(m.hand[i]))
+		zones = append(zones, clickZone{x, top, x + width, top + 1})
+		x += width
+	}
+	return zones
+}
+
+// lifeCardZone mirrors tableModel.View in the table box or the first line of
+// the compact layout.
+func (m gsModel) lifeCardZone() clickZone {
+	width := lipgloss.Width(m.table.bottomCard.renderCard(m.userGlobal.renderEmoji))
+	switch m.layout {
+	case layoutCompact:
+		x := lipgloss.Width(fmtDeckLine(m.table.deckSize))
+		return clickZone{x, 0, x + width, 1}
+	case layoutStandard, layoutWide:
+		const border = 1
+		const lifeCardLine = 2 // After "Table:" and the deck.
+		topRow := lipgloss.Height(m.boxes[0][1].style.Render(m.boxes[0][1].view))
+		y := 1 + topRow + border + lifeCardLine // Grid starts with a blank line.
+		x := lipgloss.Width(m.boxes[1][0].style.Render(m.boxes[1][0].view)) + border + lipgloss.Width("  Life Card: ")
+		return clickZone{x, y, x + width, y + 1}
+	}
+	return clickZone{}
+}
+
+// handleMouse selects a card on click and plays it on double click like the
+// play keys, so with confirmation on it takes a second double click. Clicking
+// the life card swaps it when allowed.
+func (m gsModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft ||
+		m.layout == layoutTooSmall || m.showCheat || m.chat.focused() {
+		return m, nil
+	}
+
+	// View centers the body when the help line is wider.
+	x := msg.X - (lipgloss.Width(m.View())-lipgloss.Width(m.bodyView())+1)/2
+	if m.lifeCardZone().contains(x, msg.Y) {
+		return m, m.swapBottomCard()
+	}
+
+	for i, zone := range m.handZones() {
+		if !zone.contains(x, msg.Y) {
+			continue
+		}
+		now := time.Now()
+		double := m.lastClick.card == i && now.Sub(m.lastClick.time) < DOUBLE_CLICK_TIME
+		m.selectedCard = i
+		m.lastClick = lastClick{card: i, time: now}
+		if double {
+			m.lastClick = lastClick{}
+			return m, m.requestPlay(i)
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
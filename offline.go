@@ -0,0 +1,457 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// offlineStore is an in-process stand-in for the server endpoints that are
+// not needed to play a game. It is turned on with BRISCA_OFFLINE and shared by
+// every ssh session, which makes it a local fake server for testing.
+type offlineStore struct {
+	mu      sync.Mutex
+	chats   map[string][]chatMessage
+	chatId  int
+	keymaps map[string]keymapConfig
+
+	tournaments  map[string]*tournament
+	tournamentId int
+
+	stats   map[string]playerStats
+	results map[string]bool // Game ids already counted
+	// Every counted result, for the leaderboard windows.
+	resultLog []loggedResult
+
+	daily map[string]map[string]dailyResult // By date and username
+
+	friends  map[string][]string // By username, in the order added
+	presence map[string]presenceReport
+	invites  []invite
+	inviteId int
+}
+
+type presenceReport struct {
+	presence string
+	reported time.Time
+}
+
+type loggedResult struct {
+	reported time.Time
+	result   gameResult
+}
+
+var offline = newOfflineStore()
+
+func newOfflineStore() *offlineStore {
+	return &offlineStore{
+		chats:       map[string][]chatMessage{},
+		keymaps:     map[string]keymapConfig{},
+		tournaments: map[string]*tournament{},
+		stats:       map[string]playerStats{},
+		results:     map[string]bool{},
+		daily:       map[string]map[string]dailyResult{},
+		friends:     map[string][]string{},
+		presence:    map[string]presenceReport{},
+	}
+}
+
+// offlineSession is a user's view of the offlineStore, the server knows the
+// user from the session cookie instead.
+type offlineSession struct {
+	username string
+	store    *offlineStore
+}
+
+func (m userGlobal) offlineSession() offlineSession {
+	return offlineSession{
+		username: m.username,
+		store:    offline,
+	}
+}
+
+func (m userGlobal) chatBackend() chatBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
+
+func (s offlineSession) chatRequest(room gameId, since int) []chatMessage {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var messages []chatMessage
+	for _, msg := range s.store.chats[room.GameId] {
+		if msg.Id > since {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+func (s offlineSession) sendChatRequest(room gameId, chat sendChat) bool {
+	if len([]rune(chat.Message)) > chatMaxLength {
+		return false
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	// The same rate limit as the chat box, a client can't skip it.
+	now := time.Now()
+	recent := 0
+	for _, msg := range s.store.chats[room.GameId] {
+		if msg.Username == s.username && now.Sub(msg.Sent) <= chatWindow {
+			recent++
+		}
+	}
+	if recent >= chatBurst {
+		return false
+	}
+
+	s.store.chatId++
+	s.store.chats[room.GameId] = append(s.store.chats[room.GameId], chatMessage{
+		Id:       s.store.chatId,
+		Username: s.username,
+		Message:  chat.Message,
+		Sent:     now,
+	})
+	return true
+}
+
+func (s offlineSession) keymapRequest() keymapConfig {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.keymaps[s.username].clone()
+}
+
+func (s offlineSession) saveKeymapRequest(km keymapConfig) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	s.store.keymaps[s.username] = km.clone()
+	return true
+}
+
+func (s offlineSession) tournamentsRequest() []tournament {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var tournaments []tournament
+	for _, t := range s.store.tournaments {
+		tournaments = append(tournaments, t.clone())
+	}
+	slices.SortFunc(tournaments, func(a, b tournament) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return tournaments
+}
+
+func (s offlineSession) tournamentRequest(id string) tournament {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if t, ok := s.store.tournaments[id]; ok {
+		return t.clone()
+	}
+	return tournament{}
+}
+
+func (s offlineSession) makeTournamentRequest(nt newTournament) tournament {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	s.store.tournamentId++
+	t := &tournament{
+		Id:      fmt.Sprintf("t%04d", s.store.tournamentId),
+		Name:    nt.Name,
+		Format:  nt.Format,
+		Owner:   s.username,
+		Players: []string{s.username},
+	}
+	s.store.tournaments[t.Id] = t
+	return t.clone()
+}
+
+func (s offlineSession) registerTournamentRequest(id string) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	t, ok := s.store.tournaments[id]
+	if !ok || t.Started || slices.Contains(t.Players, s.username) {
+		return false
+	}
+	t.Players = append(t.Players, s.username)
+	return true
+}
+
+func (s offlineSession) startTournamentRequest(id string) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	t, ok := s.store.tournaments[id]
+	if !ok || t.Started || t.Owner != s.username || len(t.Players) < 2 {
+		return false
+	}
+	t.start()
+	return true
+}
+
+func (s offlineSession) tournamentGameRequest(id string, game tournamentGame) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	t, ok := s.store.tournaments[id]
+	if !ok || game.Round >= len(t.Rounds) || game.Pairing >= len(t.Rounds[game.Round]) {
+		return false
+	}
+	pairing := &t.Rounds[game.Round][game.Pairing]
+	if pairing.GameId != "" || !slices.Contains(pairing.Players, s.username) {
+		return false
+	}
+	pairing.GameId = game.GameId
+	return true
+}
+
+func (s offlineSession) reportTournamentRequest(id string, result tournamentResult) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	t, ok := s.store.tournaments[id]
+	if !ok {
+		return false
+	}
+	t.report(result)
+	return true
+}
+
+func (s offlineSession) statsRequest(username string) playerStats {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	stats, ok := s.store.stats[username]
+	if !ok {
+		return newPlayerStats(username)
+	}
+	stats.Records = maps.Clone(stats.Records)
+	return stats
+}
+
+func (s offlineSession) reportResultRequest(result gameResult) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if s.store.results[result.GameId] {
+		return true
+	}
+	s.store.results[result.GameId] = true
+	s.store.resultLog = append(s.store.resultLog, loggedResult{time.Now(), result})
+
+	ratings := map[string]int{}
+	for _, p := range result.Players {
+		stats, ok := s.store.stats[p.Username]
+		if !ok {
+			stats = newPlayerStats(p.Username)
+		}
+		stats.add(result.PlayerCount, p)
+		s.store.stats[p.Username] = stats
+		ratings[p.Username] = stats.Rating
+	}
+	for username, rating := range rate(ratings, result) {
+		stats := s.store.stats[username]
+		stats.Rating = rating
+		s.store.stats[username] = stats
+	}
+	return true
+}
+
+func (s offlineSession) leaderboardRequest(query leaderboardQuery) leaderboard {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	since := windowStart(query.Window, time.Now())
+	entries := map[string]leaderboardEntry{}
+	for _, logged := range s.store.resultLog {
+		if logged.result.PlayerCount != query.PlayerCount || logged.reported.Before(since) {
+			continue
+		}
+		for _, p := range logged.result.Players {
+			entry := entries[p.Username]
+			entry.Username = p.Username
+			entry.Rating = s.store.stats[p.Username].Rating
+			entry.Played++
+			if p.Result == resultWin {
+				entry.Wins++
+			}
+			entries[p.Username] = entry
+		}
+	}
+
+	board := leaderboard{Entries: rankResults(entries)}
+	for i, entry := range board.Entries {
+		if entry.Username == s.username {
+			board.Me = &board.Entries[i]
+		}
+	}
+	return board
+}
+
+func (s offlineSession) dailyBoardRequest(date string) dailyBoard {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	board := dailyBoard{
+		Date:    date,
+		Entries: rankDaily(slices.Collect(maps.Values(s.store.daily[date]))),
+	}
+	for i, entry := range board.Entries {
+		if entry.Username == s.username {
+			board.Me = &board.Entries[i]
+		}
+	}
+	return board
+}
+
+func (s offlineSession) startDailyRequest(date string) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if date != today() {
+		return false
+	}
+	if _, ok := s.store.daily[date][s.username]; ok {
+		return false
+	}
+	if s.store.daily[date] == nil {
+		s.store.daily[date] = map[string]dailyResult{}
+	}
+	s.store.daily[date][s.username] = dailyResult{Date: date, Username: s.username}
+	return true
+}
+
+func (s offlineSession) reportDailyRequest(result dailyResult) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	started, ok := s.store.daily[result.Date][s.username]
+	if !ok || started.finished() {
+		return false
+	}
+	result.Username = s.username
+	s.store.daily[result.Date][s.username] = result
+	return true
+}
+
+func (s offlineSession) dailyReplayRequest(date, username string) []action {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.daily[date][s.username].finished() {
+		return nil
+	}
+	return s.store.daily[date][username].Actions
+}
+
+func (s offlineSession) friendsRequest() []friend {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var friends []friend
+	for _, username := range s.store.friends[s.username] {
+		presence := presenceOffline
+		if report, ok := s.store.presence[username]; ok && time.Since(report.reported) < PRESENCE_TTL {
+			presence = report.presence
+		}
+		friends = append(friends, friend{username, presence})
+	}
+	return friends
+}
+
+func (s offlineSession) addFriendRequest(username string) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if username == s.username || slices.Contains(s.store.friends[s.username], username) {
+		return false
+	}
+	s.store.friends[s.username] = append(s.store.friends[s.username], username)
+	return true
+}
+
+func (s offlineSession) removeFriendRequest(username string) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := slices.Index(s.store.friends[s.username], username)
+	if i == -1 {
+		return false
+	}
+	s.store.friends[s.username] = slices.Delete(s.store.friends[s.username], i, i+1)
+	return true
+}
+
+func (s offlineSession) presenceRequest(presence string) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	s.store.presence[s.username] = presenceReport{presence, time.Now()}
+	return true
+}
+
+// inviteRequest only invites friends, a newer invite to the same game
+// replaces the old one.
+func (s offlineSession) inviteRequest(inv sendInvite) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if inv.GameId == "" || !slices.Contains(s.store.friends[s.username], inv.To) {
+		return false
+	}
+	s.store.invites = slices.DeleteFunc(s.store.invites, func(i invite) bool {
+		return i.From == s.username && i.To == inv.To && i.GameId == inv.GameId
+	})
+	s.store.inviteId++
+	s.store.invites = append(s.store.invites, invite{
+		Id:     s.store.inviteId,
+		From:   s.username,
+		To:     inv.To,
+		GameId: inv.GameId,
+		Sent:   time.Now(),
+	})
+	return true
+}
+
+func (s offlineSession) invitesRequest() []invite {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	s.store.invites = slices.DeleteFunc(s.store.invites, func(i invite) bool {
+		return time.Since(i.Sent) >= INVITE_TTL
+	})
+	var invites []invite
+	for _, i := range s.store.invites {
+		if i.To == s.username {
+			invites = append(invites, i)
+		}
+	}
+	return invites
+}
+
+func (s offlineSession) answerInviteRequest(answer answerInvite) bool {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := slices.IndexFunc(s.store.invites, func(i invite) bool {
+		return i.Id == answer.Id && i.To == s.username
+	})
+	if i == -1 {
+		return false
+	}
+	s.store.invites = slices.Delete(s.store.invites, i, i+1)
+	return true
+}
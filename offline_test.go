@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// sessions are users of the same fresh store.
+func sessions(usernames ...string) []offlineSession {
+	store := newOfflineStore()
+	var s []offlineSession
+	for _, username := range usernames {
+		s = append(s, offlineSession{username: username, store: store})
+	}
+	return s
+}
+
+func TestOfflineChatSince(t *testing.T) {
+	s := sessions("ana", "bo")
+	ana, bo := s[0], s[1]
+	room, other := gameId{GameId: "g1"}, gameId{GameId: "g2"}
+	for _, text := range []string{"hola", "qué tal", "bien"} {
+		if !ana.sendChatRequest(room, sendChat{Message: text}) {
+			t.Fatalf("could not send %q", text)
+		}
+	}
+	bo.sendChatRequest(other, sendChat{Message: "elsewhere"})
+
+	all := bo.chatRequest(room, 0)
+	if len(all) != 3 {
+		t.Fatalf("got %d messages, want 3", len(all))
+	}
+	newer := bo.chatRequest(room, all[0].Id)
+	if len(newer) != 2 || newer[0].Message != "qué tal" {
+		t.Errorf("since %d got %v, want the last 2", all[0].Id, newer)
+	}
+	if got := bo.chatRequest(room, all[2].Id); len(got) != 0 {
+		t.Errorf("since the last message got %v, want none", got)
+	}
+	if got := bo.chatRequest(other, 0); len(got) != 1 || got[0].Username != "bo" {
+		t.Errorf("the other room got %v", got)
+	}
+}
+
+func TestOfflineChatMaxLength(t *testing.T) {
+	ana := sessions("ana")[0]
+	room := gameId{GameId: "g1"}
+	// Multibyte, the limit counts runes.
+	if !ana.sendChatRequest(room, sendChat{Message: strings.Repeat("ñ", chatMaxLength)}) {
+		t.Errorf("a message of %d runes was refused", chatMaxLength)
+	}
+	if ana.sendChatRequest(room, sendChat{Message: strings.Repeat("a", chatMaxLength+1)}) {
+		t.Errorf("a message of %d runes was sent", chatMaxLength+1)
+	}
+	if got := ana.chatRequest(room, 0); len(got) != 1 {
+		t.Errorf("got %d messages, want 1", len(got))
+	}
+}
+
+func TestOfflineChatRateLimit(t *testing.T) {
+	s := sessions("ana", "bo")
+	ana, bo := s[0], s[1]
+	room := gameId{GameId: "g1"}
+	for i := range chatBurst {
+		if !ana.sendChatRequest(room, sendChat{Message: "hola"}) {
+			t.Fatalf("message %d of the burst was refused", i+1)
+		}
+	}
+	if ana.sendChatRequest(room, sendChat{Message: "hola"}) {
+		t.Error("a message over the burst was sent")
+	}
+	if !bo.sendChatRequest(room, sendChat{Message: "hola"}) {
+		t.Error("another user was limited")
+	}
+
+	// Once the window is over the user can talk again.
+	msgs := ana.store.chats[room.GameId]
+	for i := range msgs {
+		msgs[i].Sent = msgs[i].Sent.Add(-chatWindow - time.Second)
+	}
+	if !ana.sendChatRequest(room, sendChat{Message: "hola"}) {
+		t.Error("a message after the window was refused")
+	}
+}
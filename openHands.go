@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// openHands is what the other players hold once the deck is empty: every
+// card not in our hand and not played yet.
+type openHands struct {
+	// Cards a seat certainly holds, the life card goes to the last to draw.
+	known map[int][]card
+	// The rest, split among the seats in sharedSeats.
+	shared      []card
+	sharedSeats []int
+	// Cards that are in no hand, the one left out of the deck in 3 player
+	// games.
+	leftOut int
+}
+
+func (m gsModel) openHands() openHands {
+	seen := slices.Concat(m.seenCards(), m.hand)
+	var unseen []card
+	for _, c := range fullDeck() {
+		if !slices.ContainsFunc(seen, func(s card) bool { return sameCard(s, c) }) {
+			unseen = append(unseen, c)
+		}
+	}
+
+	hands := openHands{known: map[int][]card{}}
+	inHands := 0
+	for seat := range m.gameConfig.MaxPlayers {
+		if seat != m.statusBar.mySeat && m.playerSeats[seat].handSize > 0 {
+			hands.sharedSeats = append(hands.sharedSeats, seat)
+			inHands += m.playerSeats[seat].handSize
+		}
+	}
+	hands.leftOut = max(len(unseen)-inHands, 0)
+
+	life := slices.IndexFunc(unseen, func(c card) bool { return sameCard(c, m.table.bottomCard) })
+	if life != -1 && slices.Contains(hands.sharedSeats, m.lifeHolder) {
+		hands.known[m.lifeHolder] = []card{unseen[life]}
+		unseen = slices.Delete(unseen, life, life+1)
+	}
+	if len(hands.sharedSeats) == 1 && hands.leftOut == 0 {
+		seat := hands.sharedSeats[0]
+		hands.known[seat] = append(hands.known[seat], unseen...)
+		unseen = nil
+		hands.sharedSeats = nil
+	}
+
+	slices.SortFunc(unseen, func(a, b card) int {
+		if a.suitString != b.suitString {
+			return strings.Compare(a.suitString, b.suitString)
+		}
+		return b.val - a.val
+	})
+	hands.shared = unseen
+	return hands
+}
+
+// openHandsView takes the help line's place, like the mute prompt.
+func (m gsModel) openHandsView() string {
+	hands := m.openHands()
+	render := func(cards []card) string {
+		s := ""
+		for i := range cards {
+			s += cards[i].renderCard(m.userGlobal.renderEmoji)
+		}
+		return s
+	}
+
+	var parts []string
+	for seat := range m.gameConfig.MaxPlayers {
+		if cards, ok := hands.known[seat]; ok {
+			parts = append(parts, fmt.Sprintf("%s has %s", m.playerSeats[seat].name, render(cards)))
+		}
+	}
+	if len(hands.shared) > 0 {
+		var names []string
+		for _, seat := range hands.sharedSeats {
+			names = append(names, m.playerSeats[seat].name)
+		}
+		shared := fmt.Sprintf("%s between %s", render(hands.shared), strings.Join(names, ", "))
+		if hands.leftOut > 0 {
+			shared += fmt.Sprintf(", %d left out of the deck", hands.leftOut)
+		}
+		parts = append(parts, shared)
+	}
+	if len(parts) == 0 {
+		return "Open hands: nothing left to play"
+	}
+	return "Open hands: " + strings.Join(parts, " | ")
+}
+
+// canOpenHands is once the deck is empty, playing blind the hands stay hidden.
+// Puzzles show the hands already, the daily deal keeps Daily's hand hidden
+// until it is finished since everyone plays the same cards.
+func (m gsModel) canOpenHands() bool {
+	return m.table.deckSize == 0 && !m.gameConfig.variant().blind && m.puzzle == nil && m.daily == nil
+}
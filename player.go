@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 var (
@@ -32,6 +33,11 @@ type playerModel struct {
 	boxX      int
 	boxY      int
 	afk       bool
+	swaps     int
+
+	// Shown over the box until a reactionExpiredMsg with the same id.
+	reaction   string
+	reactionId int
 
 	renderEmoji bool
 }
@@ -45,12 +51,12 @@ func (pm playerModel) UpdateScore() int {
 	return score
 }
 
-func newPlayerModelFromSeat(s seat, renderEmoji bool) playerModel {
+func newPlayerModelFromSeat(s seat, handSize int, renderEmoji bool) playerModel {
 	return playerModel{
 		name:        s.Username,
 		score:       0,
 		scorePile:   []card{},
-		handSize:    3,
+		handSize:    handSize,
 		renderEmoji: renderEmoji,
 		afk:         false,
 	}
@@ -78,8 +84,15 @@ func (pm playerModel) Update(msg tea.Msg) (playerModel, tea.Cmd) {
 func (pm playerModel) View(x, y int) string {
 	const twoNewLines int = 2
 	remainingY := y - twoNewLines
-	return fmt.Sprintf("%s Score: %d\n  Score Pile:\n%s",
-		pm.name, pm.score, pm.renderScorePile(x, remainingY))
+	header := fmt.Sprintf("%s Score: %d", pm.name, pm.score)
+	if pm.reaction != "" {
+		header = lipgloss.JoinHorizontal(lipgloss.Top,
+			header,
+			lipgloss.PlaceHorizontal(max(x-lipgloss.Width(header), 0), lipgloss.Right, pm.reaction),
+		)
+	}
+	return fmt.Sprintf("%s\n  Score Pile:\n%s",
+		header, pm.renderScorePile(x, remainingY))
 }
 
 func (pm playerModel) renderScorePile(x, y int) string {
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	pendingCardStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color("214")).
+				Foreground(lipgloss.Color("0"))
+	pendingLargeCardStyle = largeCardStyle.
+				BorderForeground(lipgloss.Color("214"))
+	pendingHintStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("214"))
+)
+
+// requestPlay is what the play keys do, with confirmation on the first press
+// highlights the card and the second press plays it.
+func (m *gsModel) requestPlay(index int) tea.Cmd {
+	if index >= len(m.hand) {
+		return nil
+	}
+	m.selectedCard = index
+	if m.userGlobal.confirmPlay && (m.confirming == nil || !sameCard(*m.confirming, m.hand[index])) {
+		c := m.hand[index]
+		m.confirming = &c
+		m.help.keys.showCancel = true
+		return nil
+	}
+	m.confirming = nil
+	return m.playOrQueue(index)
+}
+
+// playOrQueue plays the card on our turn, otherwise it is queued as a premove
+// and sent by firePremove once the turn comes around.
+func (m *gsModel) playOrQueue(index int) tea.Cmd {
+	if m.statusBar.isMyTurn() && m.statusBar.haventPlayed() {
+		m.cancelPending()
+		return m.playCard(index)
+	}
+	if m.replay || m.gameOver {
+		return nil
+	}
+	c := m.hand[index]
+	m.premove = &c
+	m.help.keys.showCancel = true
+	return nil
+}
+
+// firePremove sends the queued card as soon as it is our turn and the card is
+// in the hand, at the index it has then.
+func (m *gsModel) firePremove() tea.Cmd {
+	if m.premove == nil || !m.statusBar.isMyTurn() || !m.statusBar.haventPlayed() {
+		return nil
+	}
+	index := slices.IndexFunc(m.hand, func(c card) bool { return sameCard(c, *m.premove) })
+	if index == -1 {
+		return nil
+	}
+	m.cancelPending()
+	m.logEvent("Premove %s sent", m.hand[index].renderCard(m.userGlobal.renderEmoji))
+	return m.playCard(index)
+}
+
+// dropLostPremove is for a hand fresh from the server, a queued card that is
+// not in it took the life card's place.
+func (m *gsModel) dropLostPremove() {
+	if m.premove != nil && !slices.ContainsFunc(m.hand, func(c card) bool { return sameCard(c, *m.premove) }) {
+		m.cancelPending()
+	}
+}
+
+func (m *gsModel) cancelPending() {
+	m.premove = nil
+	m.confirming = nil
+	m.help.keys.showCancel = false
+}
+
+func (m gsModel) isPending(c card) bool {
+	return (m.premove != nil && sameCard(*m.premove, c)) ||
+		(m.confirming != nil && sameCard(*m.confirming, c))
+}
+
+// pendingHint follows the hand, it explains what the highlighted card is.
+func (m gsModel) pendingHint() string {
+	switch {
+	case m.confirming != nil:
+		return pendingHintStyle.Render(fmt.Sprintf("  Press %s again to play %s, %s to cancel",
+			m.help.keys.Enter.Help().Key, m.renderHandCard(*m.confirming),
+			m.help.keys.Cancel.Help().Key))
+	case m.premove != nil:
+		return pendingHintStyle.Render(fmt.Sprintf("  Queued %s, %s to cancel",
+			m.renderHandCard(*m.premove), m.help.keys.Cancel.Help().Key))
+	}
+	return ""
+}
+
+func sameCard(a, b card) bool {
+	return a.num == b.num && a.suitString == b.suitString
+}
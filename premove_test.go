@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+// playedBackend records the plays, the rest of the backend is never called.
+type playedBackend struct {
+	gameBackend
+	played []int
+}
+
+func (b *playedBackend) playCardRequest(index handIndex) bool {
+	b.played = append(b.played, index.Index)
+	return true
+}
+
+func TestPremoveFollowsTheCard(t *testing.T) {
+	backend := &playedBackend{}
+	m := gsModel{backend: backend, hand: cards("COPA:1", "ORO:2", "BASTO:5")}
+	m.statusBar.hasStarted = true
+	m.statusBar.mySeat, m.statusBar.turn = 0, 1
+
+	m.requestPlay(2)
+	if m.premove == nil || m.firePremove() != nil {
+		t.Fatal("the card wasn't queued for our turn")
+	}
+
+	// The hand was refreshed without the card for a moment.
+	m.hand = cards("COPA:1", "ORO:2")
+	m.statusBar.turn = 0
+	if m.firePremove() != nil || m.premove == nil {
+		t.Fatal("the premove fired or was dropped before the card was back")
+	}
+
+	m.hand = cards("ORO:2", "BASTO:5", "ESPADA:7")
+	cmd := m.firePremove()
+	if cmd == nil {
+		t.Fatal("the premove didn't fire")
+	}
+	cmd()
+	if len(backend.played) != 1 || backend.played[0] != 1 {
+		t.Errorf("played %v, want the card at its index now, 1", backend.played)
+	}
+}
+
+func TestPremoveDroppedBySwap(t *testing.T) {
+	m := gsModel{hand: cards("COPA:1", "ORO:2", "BASTO:5")}
+	m.statusBar.hasStarted = true
+	m.statusBar.mySeat, m.statusBar.turn = 0, 1
+	m.requestPlay(1)
+
+	// The 2 took the life card's place.
+	m.hand = cards("COPA:1", "ORO:7", "BASTO:5")
+	m.dropLostPremove()
+	if m.premove != nil {
+		t.Error("kept a premove for a card that left the hand")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	profileLabelStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Width(18)
+)
+
+type profileMsg playerStats
+
+// profileModel shows a player's stats and rating, it goes back to the
+// screen it was opened from.
+type profileModel struct {
+	username   string
+	stats      playerStats
+	loaded     bool
+	back       key.Binding
+	nextView   tea.Model
+	userGlobal userGlobal
+}
+
+func newProfile(nv tea.Model, userGlobal userGlobal, username string) profileModel {
+	return profileModel{
+		username:   username,
+		back:       userGlobal.keymap.binding("profile.back"),
+		nextView:   nv,
+		userGlobal: userGlobal,
+	}
+}
+
+func (m profileModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), func() tea.Msg {
+		return profileMsg(m.userGlobal.statsBackend().statsRequest(m.username))
+	})
+}
+
+func (m profileModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+	case profileMsg:
+		m.stats = playerStats(msg)
+		m.loaded = true
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		case key.Matches(msg, m.back):
+			return m.nextView, m.nextView.Init()
+		}
+	}
+	return m, nil
+}
+
+func (m profileModel) View() string {
+	lines := []string{titleStyle.Render(m.username), ""}
+	switch {
+	case !m.loaded:
+		lines = append(lines, "Loading...")
+	case m.stats.Games == 0:
+		lines = append(lines, "No games played yet.")
+	default:
+		s := m.stats
+		row := func(label string, value any) string {
+			return profileLabelStyle.Render(label) + fmt.Sprint(value)
+		}
+		lines = append(lines,
+			row("Rating", s.Rating),
+			row("Games played", s.Games),
+			row("Average points", fmt.Sprintf("%.1f", s.averagePoints())),
+			row("Aces captured", s.Aces),
+			row("Life card swaps", s.Swaps),
+			"",
+			panelTitleStyle.Render("Wins/losses/ties"),
+		)
+		for _, count := range []string{"2", "3", "4"} {
+			r, ok := s.Records[count]
+			if !ok {
+				continue
+			}
+			label := count + " players"
+			if count == "4" {
+				label = "Teams"
+			}
+			lines = append(lines, row(label, fmt.Sprintf("%d/%d/%d", r.Wins, r.Losses, r.Ties)))
+		}
+	}
+	lines = append(lines, "", helpStyle.Render(m.back.Help().Key+" "+m.back.Help().Desc))
+	return docStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+const (
+	puzzleBot = "Rival"
+	// The solver looks at every line, bigger positions take too long.
+	puzzleMaxCards = 16
+)
+
+// puzzle is a two player position with every card known, the player finds
+// the line that takes the most points against a rival that plays perfectly.
+type puzzle struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+	Goal  string `json:"goal"`
+	// The life card, the deck's last card while there is a deck.
+	Life     string   `json:"life"`
+	Hand     []string `json:"hand"`
+	Opponent []string `json:"opponent"`
+	// Top first, the rest of the game is drawn in this order.
+	Deck []string `json:"deck"`
+	// The rival's card on the table, the player leads otherwise.
+	Lead string `json:"lead"`
+}
+
+// loadPuzzles reads the embedded puzzles, the broken ones are logged and left
+// out.
+func loadPuzzles() []puzzle {
+	var all, puzzles []puzzle
+	err := json.Unmarshal([]byte(Puzzles), &all)
+	if err != nil {
+		log.Error("Could not read the puzzles", "error", err)
+		return nil
+	}
+	for _, p := range all {
+		if err := p.validate(); err != nil {
+			log.Error("Bad puzzle", "id", p.Id, "error", err)
+			continue
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles
+}
+
+func (p puzzle) validate() error {
+	deck := map[string]bool{}
+	for _, c := range fullDeck() {
+		deck[c.cardString()] = true
+	}
+	cards := slices.Concat(p.Hand, p.Opponent, p.Deck)
+	for i, c := range cards {
+		if !deck[c] {
+			return fmt.Errorf("%q is not a card", c)
+		}
+		if slices.Contains(cards[:i], c) {
+			return fmt.Errorf("%s is dealt twice", c)
+		}
+	}
+	switch {
+	case !deck[p.Life]:
+		return fmt.Errorf("the life card %q is not a card", p.Life)
+	case len(p.Deck) > 0 && p.Deck[len(p.Deck)-1] != p.Life:
+		return errors.New("the life card is not the deck's last card")
+	case len(p.Deck)%2 != 0:
+		return errors.New("two players draw an even deck")
+	case len(p.Hand) == 0 || len(p.Hand) > variants[0].handSize || len(p.Hand) != len(p.Opponent):
+		return errors.New("the hands are not the same size")
+	case len(p.Deck) > 0 && len(p.Hand) != variants[0].handSize:
+		return errors.New("the hands are full until the deck runs out")
+	case p.Lead != "" && !slices.Contains(p.Opponent, p.Lead):
+		return errors.New("the lead is not in the rival's hand")
+	case len(cards) > puzzleMaxCards:
+		return fmt.Errorf("more than %d cards", puzzleMaxCards)
+	}
+	return nil
+}
+
+// position is the table before the rival's lead.
+func (p puzzle) position() gameState {
+	s := gameState{
+		players:  2,
+		lifeSuit: newCard(p.Life).suitString,
+		hands:    [][]card{handFromStrings(p.Hand), handFromStrings(p.Opponent)},
+		deck:     handFromStrings(p.Deck),
+		leader:   localSeat,
+	}
+	if p.Lead != "" {
+		s.leader = 1
+	}
+	return s
+}
+
+// best is the player's points with perfect play on both sides.
+func (p puzzle) best() int {
+	s := p.position()
+	if p.Lead != "" {
+		s.play(slices.Index(p.Opponent, p.Lead))
+	}
+	return newSolver(s).value()
+}
+
+// known is every card the player can't see, they are all known in a puzzle.
+func (p puzzle) known() string {
+	known := puzzleBot + " started with " + notationCards(handFromStrings(p.Opponent))
+	if len(p.Deck) > 0 {
+		known += ", the deck draws " + notationCards(handFromStrings(p.Deck))
+	}
+	return known
+}
+
+func notationCards(cards []card) string {
+	var s []string
+	for _, c := range cards {
+		s = append(s, notationCard(c))
+	}
+	return strings.Join(s, " ")
+}
+
+// bestMove is the rival's play, the one that leaves the player the fewest
+// points.
+func bestMove(s gameState) int {
+	sv := newSolver(s)
+	best, bestValue := 0, -1
+	for i := range s.hands[s.turn()] {
+		if v := sv.after(i); v > bestValue {
+			best, bestValue = i, v
+		}
+	}
+	return best
+}
+
+// puzzleGame is a puzzle against Rival, it is also the backend.
+type puzzleGame struct {
+	puzzle
+	*localEngine
+}
+
+func newPuzzleGame(p puzzle, username string) *puzzleGame {
+	e := newPositionEngine(gameConfigPayload{
+		GameId:     "puzzle-" + p.Id,
+		MaxPlayers: 2,
+		Variant:    variants[0].name,
+	}, username, puzzleBot, p.position(), newCard(p.Life))
+	if p.Lead != "" {
+		e.botCards = []string{p.Lead}
+	}
+	e.botStrategy = bestMove
+	return &puzzleGame{p, e}
+}
+
+func newPuzzleGSModel(userGlobal userGlobal, p puzzle) gsModel {
+	m := newGSModel(userGlobal)
+	m.puzzle = newPuzzleGame(p, userGlobal.username)
+	m.backend = m.puzzle
+	return m
+}
+
+// puzzleResult is how a finished puzzle went.
+type puzzleResult struct {
+	id     string
+	points int
+	best   int
+}
+
+func (r puzzleResult) solved() bool {
+	return r.points >= r.best
+}
+
+func (r puzzleResult) String() string {
+	if r.solved() {
+		return fmt.Sprintf("Solved, %d points is the best line.", r.points)
+	}
+	return fmt.Sprintf("You took %d of the %d points the best line takes, enter to retry.", r.points, r.best)
+}
+
+// finishPuzzle checks the points taken against the solver and goes back to
+// the puzzles.
+func (m gsModel) finishPuzzle() (tea.Model, tea.Cmd) {
+	m.puzzle.mu.Lock()
+	result := puzzleResult{m.puzzle.Id, m.puzzle.points[localSeat], m.puzzle.best()}
+	m.puzzle.mu.Unlock()
+	if best, ok := m.userGlobal.puzzleScores[result.id]; !ok || result.points > best {
+		m.userGlobal.puzzleScores[result.id] = result.points
+	}
+	pm := newPuzzles(m.userGlobal)
+	pm.result = &result
+	return pm, pm.Init()
+}
+
+// puzzleView is the goal and the known cards, in place of the help line.
+func (m gsModel) puzzleView() string {
+	return fmt.Sprintf("%s: %s %s.", m.puzzle.Title, m.puzzle.Goal, m.puzzle.known())
+}
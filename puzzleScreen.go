@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type puzzleKeyMap struct {
+	Play key.Binding
+	Back key.Binding
+	Quit key.Binding
+}
+
+func newPuzzleKeyMap(km keymapConfig) puzzleKeyMap {
+	return puzzleKeyMap{
+		Play: km.binding("puzzles.play"),
+		Back: km.binding("puzzles.back"),
+		Quit: km.binding("puzzles.quit"),
+	}
+}
+
+// puzzleItem is a list.Item with the solver's best line and the session's
+// best try.
+type puzzleItem struct {
+	puzzle
+	best  int
+	score int
+	tried bool
+}
+
+func (i puzzleItem) Title() string {
+	return i.puzzle.Title
+}
+
+func (i puzzleItem) Description() string {
+	switch {
+	case !i.tried:
+		return fmt.Sprintf("%s · best line %d points", i.Goal, i.best)
+	case i.score >= i.best:
+		return fmt.Sprintf("%s · solved", i.Goal)
+	}
+	return fmt.Sprintf("%s · %d of %d points", i.Goal, i.score, i.best)
+}
+
+func (i puzzleItem) FilterValue() string {
+	return i.puzzle.Title
+}
+
+type puzzlesMsg []puzzleItem
+
+// puzzlesModel lists the embedded puzzles, the last result is shown on top.
+type puzzlesModel struct {
+	list       list.Model
+	keys       puzzleKeyMap
+	result     *puzzleResult
+	userGlobal userGlobal
+}
+
+func newPuzzles(userGlobal userGlobal) puzzlesModel {
+	keys := newPuzzleKeyMap(userGlobal.keymap)
+	puzzleList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	puzzleList.Styles.Title = titleStyle
+	puzzleList.Title = "Puzzles"
+	puzzleList.SetStatusBarItemName("puzzle", "puzzles")
+	puzzleList.DisableQuitKeybindings()
+	puzzleList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Play, keys.Back}
+	}
+	puzzleList.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Play, keys.Back, keys.Quit}
+	}
+	return puzzlesModel{
+		list:       puzzleList,
+		keys:       keys,
+		userGlobal: userGlobal,
+	}
+}
+
+// Init solves the puzzles in the background, it takes a moment.
+func (m puzzlesModel) Init() tea.Cmd {
+	scores := m.userGlobal.puzzleScores
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), func() tea.Msg {
+		var items []puzzleItem
+		for _, p := range loadPuzzles() {
+			score, tried := scores[p.Id]
+			items = append(items, puzzleItem{p, p.best(), score, tried})
+		}
+		return puzzlesMsg(items)
+	})
+}
+
+func (m puzzlesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		h, v := docStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v)
+
+	case puzzlesMsg:
+		var items []list.Item
+		for _, item := range msg {
+			items = append(items, item)
+		}
+		cmds = append(cmds, m.list.SetItems(items))
+		if m.result != nil {
+			// Back on the puzzle just played, enter retries it
+			m.list.Select(max(slices.IndexFunc(msg, func(i puzzleItem) bool { return i.Id == m.result.id }), 0))
+			m.list.StatusMessageLifetime = time.Second * 10
+			cmds = append(cmds, m.list.NewStatusMessage(statusMessageStyle(m.result.String())))
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			if m.list.FilterState() == list.FilterApplied {
+				break // The list clears the search first
+			}
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		case key.Matches(msg, m.keys.Play):
+			if i, ok := m.list.SelectedItem().(puzzleItem); ok {
+				pgs := newPuzzleGSModel(m.userGlobal, i.puzzle)
+				return pgs, pgs.Init()
+			}
+		}
+	}
+
+	list, cmd := m.list.Update(msg)
+	m.list = list
+	cmds = append(cmds, cmd)
+	return m, tea.Batch(cmds...)
+}
+
+func (m puzzlesModel) View() string {
+	return docStyle.Render(m.list.View())
+}
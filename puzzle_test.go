@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestPuzzleHandSizes(t *testing.T) {
+	// The last two tricks, fewer cards than a fresh deal.
+	p := puzzle{
+		Id:       "endgame",
+		Life:     "ORO:4",
+		Hand:     []string{"COPA:1", "BASTO:2"},
+		Opponent: []string{"COPA:3", "ESPADA:4"},
+	}
+	m := newPuzzleGSModel(userGlobal{username: "ana"}, p)
+	m.gameConfig = gameConfigPayload{MaxPlayers: 2}
+	seats := []seat{{Seat: localSeat, Username: "ana"}, {Seat: 1, Username: puzzleBot}}
+	players, ok := m.processSeats(seats, m.handSizes(len(seats)))().(seatsMsg)
+	if !ok {
+		t.Fatal("processSeats didn't make the seats")
+	}
+	// The seats replace the players, the puzzle's hands must survive it.
+	if players[localSeat].handSize != 2 || players[1].handSize != 2 {
+		t.Errorf("hands of %d and %d cards, want 2 and 2", players[localSeat].handSize, players[1].handSize)
+	}
+}
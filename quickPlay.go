@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	QUEUE_POLL_INTERVAL = time.Second
+	// Seconds before bots take the empty seats, 0 waits for people forever.
+	botWaitOptions = []int{30, 60, 120, 0}
+
+	queueStyle = lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("69"))
+)
+
+// quickPlayModel asks what games the player is happy to be matched into.
+type quickPlayModel struct {
+	form       *huh.Form
+	nextView   tea.Model
+	userGlobal userGlobal
+
+	playerCounts *[]int
+	swapRules    *[]string
+	botWait      *int
+}
+
+func newQuickPlay(nv tea.Model, userGlobal userGlobal) quickPlayModel {
+	playerCounts := []int{2}
+	swapRules := []string{swapPresets[0].name}
+	botWait := botWaitOptions[1]
+
+	var waits []huh.Option[int]
+	for _, wait := range botWaitOptions {
+		if wait == 0 {
+			waits = append(waits, huh.NewOption("never", wait))
+			continue
+		}
+		waits = append(waits, huh.NewOption(fmt.Sprintf("after %ds", wait), wait))
+	}
+
+	return quickPlayModel{
+		form: huh.NewForm(
+			huh.NewGroup(
+				huh.NewMultiSelect[int]().
+					Options(huh.NewOptions(2, 3, 4)...).
+					Title("Players:").
+					Validate(func(counts []int) error {
+						if len(counts) == 0 {
+							return fmt.Errorf("pick at least one")
+						}
+						return nil
+					}).
+					Value(&playerCounts),
+
+				huh.NewMultiSelect[string]().
+					Options(swapPresetOptions()...).
+					Title("Swap Life Card house rules:").
+					Validate(func(rules []string) error {
+						if len(rules) == 0 {
+							return fmt.Errorf("pick at least one")
+						}
+						return nil
+					}).
+					Value(&swapRules),
+
+				huh.NewSelect[int]().
+					Options(waits...).
+					Title("Fill empty seats with bots:").
+					Value(&botWait),
+			),
+		),
+		nextView:     nv,
+		userGlobal:   userGlobal,
+		playerCounts: &playerCounts,
+		swapRules:    &swapRules,
+		botWait:      &botWait,
+	}
+}
+
+func (m quickPlayModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+func (m quickPlayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	switch m.form.State {
+	case huh.StateAborted:
+		return m.nextView, m.nextView.Init()
+	case huh.StateCompleted:
+		queue := queueEntry{
+			PlayerCounts: *m.playerCounts,
+			SwapRules:    *m.swapRules,
+			BotWait:      *m.botWait,
+		}
+		if !m.userGlobal.rh.joinQueueRequest(queue) {
+			return m.nextView, m.nextView.Init()
+		}
+		qm := newQueue(m.userGlobal, queue)
+		return qm, qm.Init()
+	}
+
+	return m, cmd
+}
+
+func (m quickPlayModel) View() string {
+	return m.form.View()
+}
+
+type queueKeyMap struct {
+	Leave key.Binding
+	Quit  key.Binding
+}
+
+func newQueueKeyMap(km keymapConfig) queueKeyMap {
+	return queueKeyMap{
+		Leave: km.binding("queue.leave"),
+		Quit:  km.binding("queue.quit"),
+	}
+}
+
+// queueModel waits in the quick play queue until the server seats the
+// player in a game.
+type queueModel struct {
+	userGlobal userGlobal
+	queue      queueEntry
+	status     queueStatus
+	joined     time.Time
+	style      lipgloss.Style
+	keys       queueKeyMap
+}
+
+type queueStatusMsg queueStatus
+
+func newQueue(userGlobal userGlobal, queue queueEntry) queueModel {
+	return queueModel{
+		userGlobal: userGlobal,
+		queue:      queue,
+		joined:     time.Now(),
+		style:      queueStyle,
+		keys:       newQueueKeyMap(userGlobal.keymap),
+	}
+}
+
+func (m queueModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), m.poll(0))
+}
+
+func (m queueModel) poll(wait time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(wait)
+		return queueStatusMsg(m.userGlobal.rh.queueRequest())
+	}
+}
+
+func (m queueModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		m.style = m.style.
+			Width(max(windowWidthMin, msg.Width) - 2).
+			Height(max(windowHighttMin, msg.Height) - 2)
+
+	case queueStatusMsg:
+		m.status = queueStatus(msg)
+		if m.status.GameId != "" {
+			wrm := newWaitingRoom(m.userGlobal, gameId{GameId: m.status.GameId})
+			return wrm, wrm.Init()
+		}
+		return m, m.poll(QUEUE_POLL_INTERVAL)
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			m.userGlobal.rh.leaveQueueRequest()
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Leave):
+			m.userGlobal.rh.leaveQueueRequest()
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		}
+	}
+	return m, nil
+}
+
+func (m queueModel) View() string {
+	waited := time.Since(m.joined).Truncate(time.Second)
+	position := "..."
+	if m.status.Position > 0 {
+		position = fmt.Sprintf("%d of %d", m.status.Position, m.status.Queued)
+	}
+	bots := "never"
+	if m.queue.BotWait > 0 {
+		bots = fmt.Sprintf("after %ds", m.queue.BotWait)
+	}
+
+	var counts []string
+	for _, count := range m.queue.PlayerCounts {
+		counts = append(counts, fmt.Sprint(count))
+	}
+
+	s := lipgloss.JoinVertical(lipgloss.Center,
+		titleStyle.Render("Quick play"),
+		"",
+		"Looking for a game...",
+		"",
+		"Time in queue: "+waited.String(),
+		"Position: "+position,
+		"Players: "+strings.Join(counts, ", "),
+		"Swap rules: "+strings.Join(m.queue.SwapRules, ", "),
+		"Bots: "+bots,
+		"",
+		helpStyle.Render(m.keys.Leave.Help().Key+" "+m.keys.Leave.Help().Desc),
+	)
+	return m.style.Render(s)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	REACTION_LENGTH   = time.Second * 3
+	REACTION_COOLDOWN = time.Second
+
+	reactionStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("16")).
+			Background(lipgloss.Color("226")).
+			Padding(0, 1)
+)
+
+type reaction struct {
+	Name  string
+	emoji string
+	ascii string
+}
+
+// reactions are bound in order to gameScreenKeyMap.React.
+var reactions = []reaction{
+	{Name: "thumbsUp", emoji: "👍", ascii: "+1"},
+	{Name: "wow", emoji: "😮", ascii: ":O"},
+	{Name: "nice", emoji: "nice!", ascii: "nice!"},
+	{Name: "oops", emoji: "oops", ascii: "oops"},
+}
+
+func findReaction(name string) (reaction, bool) {
+	for _, r := range reactions {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return reaction{}, false
+}
+
+func (r reaction) render(renderEmoji bool) string {
+	if renderEmoji {
+		return reactionStyle.Render(r.emoji)
+	}
+	return reactionStyle.Render(r.ascii)
+}
+
+type reactionExpiredMsg struct {
+	seat int
+	id   int
+}
+
+func expireReaction(seat, id int) tea.Cmd {
+	return tea.Tick(REACTION_LENGTH, func(t time.Time) tea.Msg {
+		return reactionExpiredMsg{seat: seat, id: id}
+	})
+}
+
+func (m *gsModel) sendReaction(index int) tea.Cmd {
+	now := time.Now()
+	if m.replay || now.Sub(m.lastReaction) < REACTION_COOLDOWN {
+		return nil
+	}
+	m.lastReaction = now
+	r := reactions[index]
+	// The command runs after Update returns, m may be a copy by then.
+	backend := m.backend
+	return func() tea.Msg {
+		backend.reactRequest(react{Reaction: r.Name})
+		return nil
+	}
+}
+
+// showReaction puts the reaction over the sender's box unless they are muted.
+func (m *gsModel) showReaction(msg reactionPayload) tea.Cmd {
+	if msg.Seat < 0 || msg.Seat >= len(m.playerSeats) {
+		return nil
+	}
+	player := &m.playerSeats[msg.Seat]
+	if m.userGlobal.muted[player.name] {
+		return nil
+	}
+	r, ok := findReaction(msg.Reaction)
+	if !ok {
+		return nil
+	}
+	player.reaction = r.render(m.userGlobal.renderEmoji)
+	player.reactionId++
+	return expireReaction(msg.Seat, player.reactionId)
+}
+
+// mutePrompt lists the other players by the number that toggles their mute.
+func (m gsModel) mutePrompt() string {
+	s := "Mute/unmute reactions from:"
+	for i, seat := range m.muteSeats() {
+		name := m.playerSeats[seat].name
+		if m.userGlobal.muted[name] {
+			name += " (muted)"
+		}
+		s += fmt.Sprintf(" %d:%s", i+1, name)
+	}
+	return s + ", esc to cancel"
+}
+
+func (m gsModel) muteSeats() []int {
+	var seats []int
+	for i := range m.gameConfig.MaxPlayers {
+		if i != m.statusBar.mySeat {
+			seats = append(seats, i)
+		}
+	}
+	return seats
+}
+
+// toggleMute handles the key pressed after Mute, digits pick the player in the
+// order of the mute prompt, any other key cancels.
+func (m *gsModel) toggleMute(msg tea.KeyMsg) {
+	m.muting = false
+	seats := m.muteSeats()
+	var choice int
+	if _, err := fmt.Sscanf(msg.String(), "%d", &choice); err != nil || choice < 1 || choice > len(seats) {
+		return
+	}
+	player := &m.playerSeats[seats[choice-1]]
+	m.userGlobal.muted[player.name] = !m.userGlobal.muted[player.name]
+	if m.userGlobal.muted[player.name] {
+		player.reaction = ""
+	}
+}
@@ -51,6 +51,17 @@ type userGlobal struct {
 	username    string
 	rh          requestHandler
 	renderEmoji bool
+	largeCards  bool
+	confirmPlay bool
+	// Explain each trick in the game screen.
+	coach bool
+	// Best points in each puzzle this session.
+	puzzleScores map[string]int
+	// Players whose reactions are not shown, by username.
+	muted  map[string]bool
+	keymap keymapConfig
+	// Shared by the session, it reports what the player is doing.
+	presence *presenceTracker
 }
 
 func (m userGlobal) LastWindowSizeReplay() tea.Cmd {
@@ -59,7 +70,7 @@ func (m userGlobal) LastWindowSizeReplay() tea.Cmd {
 	}
 }
 
-func newModel(session *ssh.Session) registerModel {
+func newModel(session *ssh.Session, presence *presenceTracker) registerModel {
 
 	m := registerModel{state: textInputView}
 	m.textInput = textinput.New()
@@ -70,10 +81,13 @@ func newModel(session *ssh.Session) registerModel {
 	m.textInput.Prompt = "\tWhat's your username?\n\t\t> "
 	m.help = newHelp()
 	m.userGlobal = userGlobal{
-		session:     *session,
-		renderer:    bubbletea.MakeRenderer(*session),
-		rh:          newRequestHandler(),
-		renderEmoji: true,
+		session:      *session,
+		renderer:     bubbletea.MakeRenderer(*session),
+		rh:           newRequestHandler(),
+		renderEmoji:  true,
+		muted:        map[string]bool{},
+		puzzleScores: map[string]int{},
+		presence:     presence,
 	}
 	m.isUp = m.userGlobal.rh.statusRequest()
 
@@ -119,6 +133,8 @@ func (m registerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			register.Username = m.textInput.Value()
 			if m.userGlobal.rh.registerRequest(register) {
 				m.userGlobal.username = register.Username
+				m.userGlobal.keymap = m.userGlobal.keymapBackend().keymapRequest()
+				go m.userGlobal.presence.report(m.userGlobal.friendsBackend(), m.userGlobal.session.Context().Done())
 				lm := newLobby(m.userGlobal)
 				return lm, tea.Batch(lm.Init())
 			}
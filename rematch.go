@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	REMATCH_POLL_INTERVAL = time.Second
+	// The win screen stops polling once the game has been over this long.
+	REMATCH_TIMEOUT = time.Minute
+
+	acceptedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("10"))
+	declinedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9"))
+)
+
+const (
+	votePending  = "pending"
+	voteAccepted = "accepted"
+	voteDeclined = "declined"
+)
+
+type winScreenKeyMap struct {
+	Rematch key.Binding
+	Accept  key.Binding
+	Decline key.Binding
+}
+
+func newWinScreenKeyMap(km keymapConfig) winScreenKeyMap {
+	return winScreenKeyMap{
+		Rematch: km.binding("winScreen.rematch"),
+		Accept:  km.binding("winScreen.accept"),
+		Decline: km.binding("winScreen.decline"),
+	}
+}
+
+// gameConfig is the config to make the same game again.
+func (gc gameConfigPayload) gameConfig() gameConfig {
+	return gameConfig{
+		GameType:       gc.GameType,
+		MaxPlayers:     gc.MaxPlayers,
+		SwapBottomCard: gc.SwapBottomCard,
+		SwapRules:      gc.SwapRules,
+		Variant:        gc.Variant,
+		Match:          gc.Match,
+	}
+}
+
+type rematchStatusMsg rematchStatus
+
+// rematchAckMsg is the status right after a proposal or a vote, it doesn't
+// poll again since pollRematch is already running.
+type rematchAckMsg rematchStatus
+
+// pollRematch keeps the votes up to date until the rematch is over, anyone
+// can propose until it times out.
+func (m winScreen) pollRematch(wait time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(wait)
+		return rematchStatusMsg(m.userGlobal.rh.rematchRequest(gameId{GameId: m.gameConfig.GameId}))
+	}
+}
+
+func (m winScreen) canRematch() bool {
+	return m.gameConfig.Match == nil && m.gameConfig.Tournament == "" && !m.replay
+}
+
+// rematchOver is whether there is nothing left to poll for: we declined,
+// nobody else is coming or the offer timed out.
+func (m winScreen) rematchOver() bool {
+	if m.rematchTimedOut() || m.myVote() == voteDeclined {
+		return true
+	}
+	if m.rematch.Proposer == "" {
+		return false
+	}
+	coming := m.rematch.Proposer != m.userGlobal.username
+	for _, vote := range m.rematch.Votes {
+		switch {
+		case vote.Vote == votePending:
+			return false
+		case vote.Vote == voteAccepted && vote.Username != m.userGlobal.username:
+			coming = true
+		}
+	}
+	return !coming
+}
+
+func (m winScreen) rematchTimedOut() bool {
+	return time.Now().After(m.rematchDeadline)
+}
+
+func (m winScreen) myVote() string {
+	for _, vote := range m.rematch.Votes {
+		if vote.Username == m.userGlobal.username {
+			return vote.Vote
+		}
+	}
+	return ""
+}
+
+func (m winScreen) proposeRematch() tea.Cmd {
+	game := gameId{GameId: m.gameConfig.GameId}
+	config := m.gameConfig.gameConfig()
+	return func() tea.Msg {
+		m.userGlobal.rh.proposeRematchRequest(game, config)
+		return rematchAckMsg(m.userGlobal.rh.rematchRequest(game))
+	}
+}
+
+func (m winScreen) voteRematch(accept bool) tea.Cmd {
+	game := gameId{GameId: m.gameConfig.GameId}
+	return func() tea.Msg {
+		m.userGlobal.rh.voteRematchRequest(game, rematchVote{Accept: accept})
+		return rematchAckMsg(m.userGlobal.rh.rematchRequest(game))
+	}
+}
+
+// rematchRoom is the rematch's waiting room once the game is created,
+// everyone who accepted is already seated.
+func (m winScreen) rematchRoom() (waitingRoomModel, bool) {
+	if m.rematch.GameId == "" || m.myVote() != voteAccepted {
+		return waitingRoomModel{}, false
+	}
+	return newWaitingRoom(m.userGlobal, gameId{GameId: m.rematch.GameId}), true
+}
+
+// updateRematch handles the rematch keys, ok is false for any other key.
+func (m winScreen) updateRematch(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if m.rematchOver() {
+		return nil, false
+	}
+	proposed := m.rematch.Proposer != ""
+	switch {
+	case key.Matches(msg, m.keys.Rematch) && !proposed:
+		return m.proposeRematch(), true
+	case key.Matches(msg, m.keys.Accept) && proposed && m.myVote() == votePending:
+		return m.voteRematch(true), true
+	case key.Matches(msg, m.keys.Decline) && proposed && m.myVote() == votePending:
+		return m.voteRematch(false), true
+	}
+	return nil, false
+}
+
+// leave declines a pending rematch on the way to the lobby, so nobody waits
+// for us.
+func (m winScreen) leave() (tea.Model, tea.Cmd) {
+	lm := newLobby(m.userGlobal)
+	return lm, tea.Batch(lm.Init(), m.declineRematch())
+}
+
+func (m winScreen) declineRematch() tea.Cmd {
+	if m.rematch.Proposer == "" || m.myVote() != votePending {
+		return nil
+	}
+	rh, game := m.userGlobal.rh, gameId{GameId: m.gameConfig.GameId}
+	return func() tea.Msg {
+		rh.voteRematchRequest(game, rematchVote{Accept: false})
+		return nil
+	}
+}
+
+func (m winScreen) rematchView() string {
+	switch {
+	case m.rematch.Proposer == "" && m.rematchTimedOut():
+		return "Too late for a rematch"
+	case m.rematch.Proposer == "":
+		return fmt.Sprintf("%s to propose a rematch", m.keys.Rematch.Help().Key)
+	}
+	lines := []string{m.rematch.Proposer + " proposed a rematch:"}
+	for _, vote := range m.rematch.Votes {
+		switch vote.Vote {
+		case voteAccepted:
+			lines = append(lines, acceptedStyle.Render(vote.Username+" accepted"))
+		case voteDeclined:
+			lines = append(lines, declinedStyle.Render(vote.Username+" declined"))
+		default:
+			lines = append(lines, vote.Username+" is deciding...")
+		}
+	}
+	switch {
+	case m.rematchOver():
+		lines = append(lines, "No rematch this time")
+	case m.myVote() == votePending:
+		lines = append(lines, fmt.Sprintf("%s to accept, %s to decline",
+			m.keys.Accept.Help().Key, m.keys.Decline.Help().Key))
+	}
+	return strings.Join(lines, "\n")
+}
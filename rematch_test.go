@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRematchOver(t *testing.T) {
+	votes := func(v ...string) []rematchPlayer {
+		var players []rematchPlayer
+		for i := 0; i < len(v); i += 2 {
+			players = append(players, rematchPlayer{Username: v[i], Vote: v[i+1]})
+		}
+		return players
+	}
+	tests := []struct {
+		name    string
+		rematch rematchStatus
+		want    bool
+	}{
+		{"nobody proposed", rematchStatus{}, false},
+		{"bo is deciding", rematchStatus{Proposer: "ana", Votes: votes("ana", voteAccepted, "bo", votePending)}, false},
+		{"bo declined", rematchStatus{Proposer: "ana", Votes: votes("ana", voteAccepted, "bo", voteDeclined)}, true},
+		{"ana declined", rematchStatus{Proposer: "bo", Votes: votes("bo", voteAccepted, "ana", voteDeclined)}, true},
+		{"ana accepted bo's", rematchStatus{Proposer: "bo", Votes: votes("bo", voteAccepted, "ana", voteAccepted)}, false},
+		{"cy is still coming", rematchStatus{Proposer: "ana", Votes: votes("ana", voteAccepted, "bo", voteDeclined, "cy", voteAccepted)}, false},
+	}
+	for _, tt := range tests {
+		m := winScreen{
+			userGlobal:      userGlobal{username: "ana"},
+			rematch:         tt.rematch,
+			rematchDeadline: time.Now().Add(REMATCH_TIMEOUT),
+		}
+		if got := m.rematchOver(); got != tt.want {
+			t.Errorf("%s: over is %v, want %v", tt.name, got, tt.want)
+		}
+		m.rematchDeadline = time.Now().Add(-time.Second)
+		if !m.rematchOver() {
+			t.Errorf("%s: not over after the timeout", tt.name)
+		}
+	}
+}
+
+func TestDeclineRematch(t *testing.T) {
+	m := winScreen{userGlobal: userGlobal{username: "ana"}}
+	if m.declineRematch() != nil {
+		t.Error("declined a rematch nobody proposed")
+	}
+	m.rematch = rematchStatus{Proposer: "bo", Votes: []rematchPlayer{{"bo", voteAccepted}, {"ana", voteAccepted}}}
+	if m.declineRematch() != nil {
+		t.Error("declined a rematch ana accepted")
+	}
+	m.rematch.Votes[1].Vote = votePending
+	if m.declineRematch() == nil {
+		t.Error("left without declining a pending rematch")
+	}
+}
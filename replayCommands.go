@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+// ReplayCommandMiddleware serves the replay files without a terminal:
+//
+//	ssh brisca.sh export <username> <gameId> > game.json
+//	ssh <username>@brisca.sh import <username> < game.json
+//	ssh brisca.sh daily [YYYY-MM-DD]
+//
+// The username is the one typed at the register screen, the commands sign
+// in with it the same way and imported games show up in its history. Only
+// the account's own SSH user can import into it. The daily command prints a
+// past daily deal and its seed, yesterday's by default, to check them
+// against the hash shown on the day and the replays.
+func ReplayCommandMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			command := sess.Command()
+			if len(command) == 0 {
+				next(sess)
+				return
+			}
+
+			switch {
+			case command[0] == "export" && len(command) == 3:
+				username, game := command[1], command[2]
+				rh, ok := commandAccount(username)
+				if !ok {
+					wish.Fatalln(sess, "Could not sign in as "+username+".")
+					return
+				}
+				actions := loadImportedReplay(username, game)
+				if actions == nil {
+					actions = rh.replayRequest(gameId{GameId: game})
+				}
+				if actions == nil {
+					wish.Fatalln(sess, "No replay for game "+game+".")
+					return
+				}
+				err := writeReplayFile(sess, newReplayFile(game, actions))
+				if err != nil {
+					log.Error("Could not export the replay", "error", err)
+					wish.Fatalln(sess, "Could not export the replay.")
+					return
+				}
+			case command[0] == "import" && len(command) == 2:
+				username := command[1]
+				if username != sess.User() {
+					wish.Fatalln(sess, "Import as yourself: ssh "+username+"@brisca.sh import "+username+".")
+					return
+				}
+				if _, ok := commandAccount(username); !ok {
+					wish.Fatalln(sess, "Could not sign in as "+username+".")
+					return
+				}
+				f, err := readReplayFile(sess)
+				if err != nil {
+					wish.Fatalln(sess, err.Error())
+					return
+				}
+				err = importReplay(username, f)
+				if errors.Is(err, errReplayExists) {
+					wish.Fatalln(sess, "Game "+f.GameId+" is already in the history.")
+					return
+				}
+				if err != nil {
+					log.Error("Could not import the replay", "error", err)
+					wish.Fatalln(sess, "Could not import the replay.")
+					return
+				}
+				wish.Println(sess, fmt.Sprintf("Imported game %s, replay it from the game history in the lobby.", f.GameId))
+			case command[0] == "daily" && len(command) <= 2:
+				date := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+				if len(command) == 2 {
+					date = command[1]
+				}
+				if _, err := time.Parse(time.DateOnly, date); err != nil {
+					wish.Fatalln(sess, "The date is YYYY-MM-DD.")
+					return
+				}
+				if !dailyOver(date) {
+					commitment := dailyCommitment(date)
+					wish.Fatalln(sess, "The deal for "+date+" is secret until the day is over, its seed hashes to "+hex.EncodeToString(commitment[:])+".")
+					return
+				}
+				for _, line := range dailyDealLines(date) {
+					wish.Println(sess, line)
+				}
+			default:
+				wish.Fatalln(sess, "Usage: export <username> <gameId> | import <username> | daily [YYYY-MM-DD]")
+			}
+		}
+	}
+}
+
+// commandAccount signs in like the register screen, with the same username
+// rules, and the game server decides if the name can be used.
+func commandAccount(username string) (requestHandler, bool) {
+	rh := newRequestHandler()
+	if username == "" || len([]rune(username)) > 25 || strings.ContainsFunc(username, func(r rune) bool { return !okChars(r) }) {
+		return rh, false
+	}
+	return rh, rh.registerRequest(register{Username: username})
+}
+
+// sshCommand runs a command on this server.
+func sshCommand(command string) string {
+	host, port := env.Host, env.Port
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "brisca.sh" // Listening on every address, like in the Dockerfile
+	}
+	if port == "22" {
+		return fmt.Sprintf("ssh %s %s", host, command)
+	}
+	return fmt.Sprintf("ssh -p %s %s %s", port, host, command)
+}
+
+// exportCommand is what the game history shows to export a game.
+func exportCommand(username, gameId string) string {
+	return sshCommand(fmt.Sprintf("export %s %s > %s.json", username, gameId, gameId))
+}
+
+// dailyCommand is what the daily board shows to check a deal.
+func dailyCommand(date string) string {
+	return sshCommand("daily " + date)
+}
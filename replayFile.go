@@ -0,0 +1,286 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	replayFormat  = "brisca-replay"
+	replayVersion = 1
+	// A finished game is a few dozen kilobytes.
+	replayMaxSize = 1 << 20
+)
+
+// replayFile is a game's actions in a portable file, the notation is only for
+// people reading it, the actions are what gets replayed.
+type replayFile struct {
+	Format   string            `json:"format"`
+	Version  int               `json:"version"`
+	GameId   string            `json:"gameId"`
+	Exported time.Time         `json:"exported"`
+	Players  []string          `json:"players"`
+	Config   gameConfigPayload `json:"config"`
+	Notation []string          `json:"notation"`
+	Actions  []action          `json:"actions"`
+}
+
+func newReplayFile(gameId string, actions []action) replayFile {
+	f := replayFile{
+		Format:   replayFormat,
+		Version:  replayVersion,
+		GameId:   gameId,
+		Exported: time.Now(),
+		Actions:  actions,
+	}
+	for _, a := range actions {
+		switch payload := a.Payload.(type) {
+		case gameConfigPayload:
+			f.Config = payload
+		case gameStartedPayload:
+			f.Players = seatNames(payload.Seats)
+		}
+	}
+	f.Notation = trickNotation(actions)
+	return f
+}
+
+func seatNames(seats []seat) []string {
+	names := make([]string, len(seats))
+	for _, s := range seats {
+		if s.Seat >= 0 && s.Seat < len(names) {
+			names[s.Seat] = s.Username
+		}
+	}
+	return names
+}
+
+func notationCard(c card) string {
+	return fmt.Sprintf("%d%s", c.num, c.charSuit)
+}
+
+// trickNotation is one line per trick, "3. ana 7Or, bo 1Co: bo +11", with the
+// life card, swaps and the result around them.
+func trickNotation(actions []action) []string {
+	var (
+		lines   []string
+		players []string
+		trick   []string
+		points  int
+		tricks  int
+	)
+	name := func(seat int) string {
+		if seat >= 0 && seat < len(players) {
+			return players[seat]
+		}
+		return fmt.Sprintf("seat %d", seat)
+	}
+	for _, a := range actions {
+		switch payload := a.Payload.(type) {
+		case gameConfigPayload:
+			lines = append(lines, fmt.Sprintf("Game %s, %d players, %s",
+				payload.GameId, payload.MaxPlayers, payload.variant().name))
+		case gameStartedPayload:
+			players = seatNames(payload.Seats)
+			lines = append(lines, "Players: "+strings.Join(players, ", "))
+		case bottomCardSelectedPayload:
+			lines = append(lines, "Life: "+notationCard(payload.bottomCard))
+		case swapBottomCardPayload:
+			swap := "Swap"
+			if payload.Card != "" {
+				swap += ": " + notationCard(newCard(payload.Card))
+			}
+			lines = append(lines, swap)
+		case cardPlayedPayload:
+			trick = append(trick, name(payload.Seat)+" "+notationCard(payload.card))
+			points += payload.card.score
+		case turnWonPayload:
+			tricks++
+			lines = append(lines, fmt.Sprintf("%d. %s: %s +%d",
+				tricks, strings.Join(trick, ", "), name(payload.Seat), points))
+			trick, points = nil, 0
+		case gameWonPayload:
+			switch {
+			case payload.Team == "draw" || payload.Seat == -1:
+				lines = append(lines, "Result: draw")
+			case payload.Team != "":
+				lines = append(lines, "Result: team "+payload.Team+" wins")
+			default:
+				lines = append(lines, "Result: "+name(payload.Seat)+" wins")
+			}
+		}
+	}
+	return lines
+}
+
+func writeReplayFile(w io.Writer, f replayFile) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(f)
+}
+
+func readReplayFile(r io.Reader) (replayFile, error) {
+	var f replayFile
+	data, err := io.ReadAll(io.LimitReader(r, replayMaxSize+1))
+	if err != nil {
+		return f, fmt.Errorf("could not read the replay: %w", err)
+	}
+	if len(data) > replayMaxSize {
+		return f, fmt.Errorf("the replay is over %d KiB", replayMaxSize>>10)
+	}
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return f, fmt.Errorf("not a replay file: %w", err)
+	}
+	if f.Format != replayFormat {
+		return f, fmt.Errorf("not a replay file, the format is %q", f.Format)
+	}
+	if f.Version > replayVersion {
+		return f, fmt.Errorf("replay version %d is newer than this client's %d", f.Version, replayVersion)
+	}
+	if f.GameId == "" {
+		f.GameId = f.Config.GameId
+	}
+	if f.GameId == "" || len(f.Actions) == 0 {
+		return f, errors.New("the replay has no game")
+	}
+	if err := validateActions(f.Actions); err != nil {
+		return f, fmt.Errorf("the replay is broken: %w", err)
+	}
+	return f, nil
+}
+
+// validateActions checks what the game screen and the analysis index with:
+// every seat is one of the game's and every card is in the deck and played
+// from within a hand.
+func validateActions(actions []action) error {
+	deck := map[string]bool{}
+	for _, c := range fullDeck() {
+		deck[c.cardString()] = true
+	}
+	players, handSize := 0, 0
+	checkSeat := func(i, seat int) error {
+		if players == 0 {
+			return fmt.Errorf("action %d: a seat before the game config", i)
+		}
+		if seat < 0 || seat >= players {
+			return fmt.Errorf("action %d: seat %d in a %d player game", i, seat, players)
+		}
+		return nil
+	}
+	checkCard := func(i int, c string) error {
+		if !deck[c] {
+			return fmt.Errorf("action %d: %q is not a card", i, c)
+		}
+		return nil
+	}
+
+	for i, a := range actions {
+		var err error
+		switch payload := a.Payload.(type) {
+		case gameConfigPayload:
+			if payload.MaxPlayers < 2 || payload.MaxPlayers > 4 {
+				return fmt.Errorf("action %d: %d players", i, payload.MaxPlayers)
+			}
+			players, handSize = payload.MaxPlayers, payload.variant().handSize
+		case gameStartedPayload:
+			if len(payload.Seats) > players {
+				return fmt.Errorf("action %d: %d seats in a %d player game", i, len(payload.Seats), players)
+			}
+			for _, s := range payload.Seats {
+				if err := checkSeat(i, s.Seat); err != nil {
+					return err
+				}
+			}
+			err = checkSeat(i, payload.StartingSeat)
+		case bottomCardSelectedPayload:
+			err = checkCard(i, payload.BottomCard)
+		case swapBottomCardPayload:
+			if payload.Card != "" {
+				err = checkCard(i, payload.Card)
+			}
+		case cardDrawnPayload:
+			err = checkSeat(i, payload.Seat)
+		case cardPlayedPayload:
+			err = cmp.Or(checkSeat(i, payload.Seat), checkCard(i, payload.Card))
+			if err == nil && (payload.Index < 0 || payload.Index >= handSize) {
+				err = fmt.Errorf("action %d: card %d of a %d card hand", i, payload.Index, handSize)
+			}
+		case turnWonPayload:
+			err = checkSeat(i, payload.Seat)
+		case gameWonPayload:
+			if payload.Seat != -1 {
+				err = checkSeat(i, payload.Seat)
+			}
+		case reactionPayload:
+			err = checkSeat(i, payload.Seat)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importedReplayPath keeps imported replays next to the account's history.
+func importedReplayPath(username, gameId string) string {
+	return filepath.Join(env.History, url.PathEscape(username)+".replays", url.PathEscape(gameId)+".json")
+}
+
+// errReplayExists is for a game already in the account's history, played or
+// imported, its replay is not replaced.
+var errReplayExists = errors.New("the game is already in the history")
+
+// importReplay stores the replay and lists it in the account's history.
+func importReplay(username string, f replayFile) error {
+	if slices.ContainsFunc(loadHistory(username), func(e historyEntry) bool { return e.GameId == f.GameId }) {
+		return errReplayExists
+	}
+	path := importedReplayPath(username, f.GameId)
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	err = writeReplayFile(out, f)
+	if err != nil {
+		return err
+	}
+	if !saveHistory(username, historyEntry{
+		GameId:   f.GameId,
+		Date:     f.Exported,
+		Players:  f.Players,
+		Result:   resultImported,
+		Config:   f.Config,
+		Imported: true,
+	}) {
+		return errors.New("could not save the game history")
+	}
+	return nil
+}
+
+func loadImportedReplay(username, gameId string) []action {
+	in, err := os.Open(importedReplayPath(username, gameId))
+	if err != nil {
+		return nil
+	}
+	defer in.Close()
+	f, err := readReplayFile(in)
+	if err != nil {
+		return nil
+	}
+	return f.Actions
+}
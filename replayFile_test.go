@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// shortGame is a game cut down to one trick, enough for the file format.
+func shortGame() []action {
+	played := func(seat, index int, c string) action {
+		return action{Type: "CARD_PLAYED", Payload: cardPlayedPayload{Seat: seat, Index: index, Card: c, card: newCard(c)}}
+	}
+	return []action{
+		{Type: "GAME_CONFIG", Payload: gameConfigPayload{GameId: "g1", MaxPlayers: 2}},
+		{Type: "GAME_STARTED", Payload: gameStartedPayload{Seats: []seat{{Seat: 0, Username: "ana"}, {Seat: 1, Username: "bo"}}}},
+		{Type: "BOTTOM_CARD_SELECTED", Payload: bottomCardSelectedPayload{BottomCard: "ORO:4", bottomCard: newCard("ORO:4")}},
+		played(0, 1, "COPA:3"),
+		played(1, 0, "COPA:1"),
+		{Type: "TURN_WON", Payload: turnWonPayload{Seat: 1}},
+		{Type: "GAME_WON", Payload: gameWonPayload{Seat: 1}},
+	}
+}
+
+func TestReplayFileRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeReplayFile(&buf, newReplayFile("g1", shortGame()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := readReplayFile(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.GameId != "g1" || !slices.Equal(f.Players, []string{"ana", "bo"}) || f.Config.MaxPlayers != 2 {
+		t.Errorf("got game %s with %v and %+v", f.GameId, f.Players, f.Config)
+	}
+	want := shortGame()
+	if len(f.Actions) != len(want) {
+		t.Fatalf("got %d actions, want %d", len(f.Actions), len(want))
+	}
+	for i := range want {
+		if f.Actions[i].Type != want[i].Type {
+			t.Errorf("action %d is %s, want %s", i, f.Actions[i].Type, want[i].Type)
+		}
+	}
+	// The unexported cards are read back from the card strings.
+	if p, ok := f.Actions[4].Payload.(cardPlayedPayload); !ok || p != want[4].Payload {
+		t.Errorf("the second card played is %+v, want %+v", f.Actions[4].Payload, want[4].Payload)
+	}
+	if p, ok := f.Actions[2].Payload.(bottomCardSelectedPayload); !ok || p != want[2].Payload {
+		t.Errorf("the life card is %+v, want %+v", f.Actions[2].Payload, want[2].Payload)
+	}
+
+	notation := strings.Join(f.Notation, "\n")
+	for _, line := range []string{"Life: 4Or", "1. ana 3Co, bo 1Co: bo +21", "Result: bo wins"} {
+		if !strings.Contains(notation, line) {
+			t.Errorf("the notation is missing %q:\n%s", line, notation)
+		}
+	}
+}
+
+func TestReadReplayFileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"not json", "brisca"},
+		{"another format", `{"format": "pgn", "version": 1, "gameId": "g1", "actions": [{"type": "GAME_WON", "payload": {"seat": 0}}]}`},
+		{"a newer version", `{"format": "brisca-replay", "version": 2, "gameId": "g1", "actions": [{"type": "GAME_WON", "payload": {"seat": 0}}]}`},
+		{"no actions", `{"format": "brisca-replay", "version": 1, "gameId": "g1", "actions": []}`},
+	}
+	for _, tt := range tests {
+		if _, err := readReplayFile(strings.NewReader(tt.file)); err == nil {
+			t.Errorf("%s was read", tt.name)
+		}
+	}
+}
+
+func TestImportReplay(t *testing.T) {
+	env.History = t.TempDir()
+	f := newReplayFile("g1", shortGame())
+	if err := importReplay("ana", f); err != nil {
+		t.Fatal(err)
+	}
+
+	history := loadHistory("ana")
+	if len(history) != 1 || history[0].GameId != "g1" || !history[0].Imported {
+		t.Errorf("the history is %+v", history)
+	}
+	if actions := loadImportedReplay("ana", "g1"); len(actions) != len(f.Actions) {
+		t.Errorf("loaded %d actions, want %d", len(actions), len(f.Actions))
+	}
+	if actions := loadImportedReplay("bo", "g1"); actions != nil {
+		t.Error("another account loaded the replay")
+	}
+}
+
+func TestValidateActions(t *testing.T) {
+	if err := validateActions(shortGame()); err != nil {
+		t.Fatalf("a good game is broken: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(actions []action) []action
+	}{
+		{"a seat before the config", func(actions []action) []action {
+			return actions[1:]
+		}},
+		{"5 players", func(actions []action) []action {
+			actions[0].Payload = gameConfigPayload{GameId: "g1", MaxPlayers: 5}
+			return actions
+		}},
+		{"a card played by a missing seat", func(actions []action) []action {
+			actions[3].Payload = cardPlayedPayload{Seat: 2, Index: 0, Card: "COPA:3"}
+			return actions
+		}},
+		{"a card played from outside the hand", func(actions []action) []action {
+			actions[3].Payload = cardPlayedPayload{Seat: 0, Index: 3, Card: "COPA:3"}
+			return actions
+		}},
+		{"a card that is not in the deck", func(actions []action) []action {
+			actions[3].Payload = cardPlayedPayload{Seat: 0, Index: 0, Card: "COPA:8"}
+			return actions
+		}},
+		{"a life card that is not in the deck", func(actions []action) []action {
+			actions[2].Payload = bottomCardSelectedPayload{BottomCard: "ORO"}
+			return actions
+		}},
+		{"a trick won by a missing seat", func(actions []action) []action {
+			actions[5].Payload = turnWonPayload{Seat: -1}
+			return actions
+		}},
+	}
+	for _, tt := range tests {
+		if err := validateActions(tt.change(shortGame())); err == nil {
+			t.Errorf("%s is not broken", tt.name)
+		}
+	}
+
+	// A draw is won by seat -1, the fifth card only exists in five.
+	actions := shortGame()
+	actions[0].Payload = gameConfigPayload{GameId: "g1", MaxPlayers: 2, Variant: "five"}
+	actions[3].Payload = cardPlayedPayload{Seat: 0, Index: 4, Card: "COPA:3"}
+	actions[6].Payload = gameWonPayload{Seat: -1}
+	if err := validateActions(actions); err != nil {
+		t.Errorf("a five card draw is broken: %v", err)
+	}
+}
+
+func TestImportReplayTwice(t *testing.T) {
+	env.History = t.TempDir()
+	if err := importReplay("ana", newReplayFile("g1", shortGame())); err != nil {
+		t.Fatal(err)
+	}
+	err := importReplay("ana", newReplayFile("g1", shortGame()[:1]))
+	if !errors.Is(err, errReplayExists) {
+		t.Errorf("imported the game again, error %v", err)
+	}
+	if actions := loadImportedReplay("ana", "g1"); len(actions) != len(shortGame()) {
+		t.Errorf("the replay was replaced, it has %d actions", len(actions))
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/log"
@@ -29,18 +31,49 @@ type register struct {
 }
 
 type game struct {
-	GameId string `json:"gameId"`
-	Fill   string `json:"fill"`
+	GameId  string `json:"gameId"`
+	Fill    string `json:"fill"`
+	Variant string `json:"variant"`
 }
 
-func (g game) Title() string       { return g.GameId }
-func (g game) Description() string { return "Fill: " + g.Fill }
+func (g game) Title() string { return g.GameId }
+func (g game) Description() string {
+	return "Fill: " + g.Fill + ", " + findVariant(g.Variant).name
+}
 func (g game) FilterValue() string { return g.GameId }
 
 type gameConfig struct {
-	GameType       string `json:"gameType"`
-	MaxPlayers     int    `json:"maxPlayers"`
-	SwapBottomCard bool   `json:"swapBottomCard"`
+	GameType       string     `json:"gameType"`
+	MaxPlayers     int        `json:"maxPlayers"`
+	SwapBottomCard bool       `json:"swapBottomCard"`
+	SwapRules      []swapRule `json:"swapRules"`
+	Variant        string     `json:"variant"`
+	// Nil for a single game.
+	Match *matchConfig `json:"match,omitempty"`
+	// The tournament the game is a pairing of.
+	Tournament string `json:"tournament,omitempty"`
+}
+
+// matchConfig makes a game the first of a match, the same players play
+// Target games, or until someone has won Target games.
+type matchConfig struct {
+	Mode   string `json:"mode"`
+	Target int    `json:"target"`
+}
+
+type matchGame struct {
+	GameId string `json:"gameId"`
+	Winner string `json:"winner"` // Username or team, empty on a tie
+}
+
+type matchStatus struct {
+	MatchId string         `json:"matchId"`
+	Games   []matchGame    `json:"games"`
+	Wins    map[string]int `json:"wins"`
+	// Set once the server has seated everyone in the next game.
+	NextGameId string `json:"nextGameId"`
+	Over       bool   `json:"over"`
+	Winner     string `json:"winner"`
 }
 
 type gamesList struct {
@@ -522,8 +555,13 @@ func (m requestHandler) changeTeamRequest(spectator bool) bool {
 	return true
 }
 
-func (m requestHandler) swapBottomCardRequest() bool {
-	reader := bytes.NewReader([]byte{})
+type swapBottomCard struct {
+	Card string `json:"card"`
+}
+
+func (m requestHandler) swapBottomCardRequest(swap swapBottomCard) bool {
+	payload, _ := json.Marshal(swap)
+	reader := bytes.NewReader(payload)
 
 	requestURL := fmt.Sprintf("%s/swapBottomCard", env.Server)
 
@@ -579,3 +617,988 @@ func (m requestHandler) replayRequest(gameId gameId) []action {
 
 	return actions
 }
+
+func (m requestHandler) matchRequest(gameId gameId) matchStatus {
+	requestURL := fmt.Sprintf("%s/match?gameId=%s", env.Server, gameId.GameId)
+	match := matchStatus{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return match
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return match
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return match
+	}
+
+	json.Unmarshal([]byte(body.String()), &match)
+
+	return match
+}
+
+type rematchStatus struct {
+	Proposer string          `json:"proposer"`
+	Votes    []rematchPlayer `json:"votes"`
+	// Set once everyone who accepted is seated in the new game.
+	GameId string `json:"gameId"`
+}
+
+type rematchPlayer struct {
+	Username string `json:"username"`
+	Vote     string `json:"vote"`
+}
+
+type rematchVote struct {
+	Accept bool `json:"accept"`
+}
+
+func (m requestHandler) rematchRequest(gameId gameId) rematchStatus {
+	requestURL := fmt.Sprintf("%s/rematch?gameId=%s", env.Server, gameId.GameId)
+	rematch := rematchStatus{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return rematch
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return rematch
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return rematch
+	}
+
+	json.Unmarshal([]byte(body.String()), &rematch)
+
+	return rematch
+}
+
+// proposeRematchRequest asks the same players for a new game with the config.
+func (m requestHandler) proposeRematchRequest(gameId gameId, gc gameConfig) bool {
+	payload, _ := json.Marshal(gc)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/rematch?gameId=%s", env.Server, gameId.GameId)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) voteRematchRequest(gameId gameId, vote rematchVote) bool {
+	payload, _ := json.Marshal(vote)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/rematch/vote?gameId=%s", env.Server, gameId.GameId)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+// queueEntry are the games a player is happy to be matched into.
+type queueEntry struct {
+	PlayerCounts []int    `json:"playerCounts"`
+	SwapRules    []string `json:"swapRules"`
+	BotWait      int      `json:"botWait"` // Seconds, 0 never adds bots
+}
+
+type queueStatus struct {
+	Position int `json:"position"`
+	Queued   int `json:"queued"`
+	// Set once the player is seated in a game.
+	GameId string `json:"gameId"`
+}
+
+func (m requestHandler) joinQueueRequest(queue queueEntry) bool {
+	payload, _ := json.Marshal(queue)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/queue", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) queueRequest() queueStatus {
+	requestURL := fmt.Sprintf("%s/queue", env.Server)
+	status := queueStatus{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return status
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return status
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return status
+	}
+
+	json.Unmarshal([]byte(body.String()), &status)
+
+	return status
+}
+
+func (m requestHandler) leaveQueueRequest() bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/queue/leave", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+type tournamentsList struct {
+	Tournaments []tournament `json:"tournaments"`
+}
+
+func (m requestHandler) tournamentsRequest() []tournament {
+	requestURL := fmt.Sprintf("%s/tournaments", env.Server)
+	result := tournamentsList{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result.Tournaments
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result.Tournaments
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result.Tournaments
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result.Tournaments
+}
+
+func (m requestHandler) tournamentRequest(id string) tournament {
+	requestURL := fmt.Sprintf("%s/tournament?id=%s", env.Server, url.QueryEscape(id))
+	result := tournament{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) makeTournamentRequest(nt newTournament) tournament {
+	payload, _ := json.Marshal(nt)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/tournament", env.Server)
+	result := tournament{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) registerTournamentRequest(id string) bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/tournament/register?id=%s", env.Server, url.QueryEscape(id))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) startTournamentRequest(id string) bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/tournament/start?id=%s", env.Server, url.QueryEscape(id))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) tournamentGameRequest(id string, game tournamentGame) bool {
+	payload, _ := json.Marshal(game)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/tournament/game?id=%s", env.Server, url.QueryEscape(id))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) reportTournamentRequest(id string, result tournamentResult) bool {
+	payload, _ := json.Marshal(result)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/tournament/report?id=%s", env.Server, url.QueryEscape(id))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) statsRequest(username string) playerStats {
+	requestURL := fmt.Sprintf("%s/stats?username=%s", env.Server, url.QueryEscape(username))
+	result := newPlayerStats(username)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) reportResultRequest(result gameResult) bool {
+	payload, _ := json.Marshal(result)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/result", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) leaderboardRequest(query leaderboardQuery) leaderboard {
+	requestURL := fmt.Sprintf("%s/leaderboard?playerCount=%d&window=%s", env.Server, query.PlayerCount, query.Window)
+	result := leaderboard{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) dailyBoardRequest(date string) dailyBoard {
+	requestURL := fmt.Sprintf("%s/daily?date=%s", env.Server, url.QueryEscape(date))
+	result := dailyBoard{}
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) startDailyRequest(date string) bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/daily/start?date=%s", env.Server, url.QueryEscape(date))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) reportDailyRequest(result dailyResult) bool {
+	payload, _ := json.Marshal(result)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/daily/result", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) dailyReplayRequest(date, username string) []action {
+	requestURL := fmt.Sprintf("%s/daily/replay?date=%s&username=%s", env.Server, url.QueryEscape(date), url.QueryEscape(username))
+	var result []action
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) friendsRequest() []friend {
+	requestURL := fmt.Sprintf("%s/friends", env.Server)
+	var result []friend
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) addFriendRequest(username string) bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/friends/add?username=%s", env.Server, url.QueryEscape(username))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) removeFriendRequest(username string) bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/friends/remove?username=%s", env.Server, url.QueryEscape(username))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) presenceRequest(presence string) bool {
+	reader := bytes.NewReader([]byte{})
+	requestURL := fmt.Sprintf("%s/presence?presence=%s", env.Server, url.QueryEscape(presence))
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) inviteRequest(invite sendInvite) bool {
+	payload, _ := json.Marshal(invite)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/invite", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) invitesRequest() []invite {
+	requestURL := fmt.Sprintf("%s/invites", env.Server)
+	var result []invite
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return result
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return result
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return result
+	}
+
+	json.Unmarshal([]byte(body.String()), &result)
+
+	return result
+}
+
+func (m requestHandler) answerInviteRequest(answer answerInvite) bool {
+	payload, _ := json.Marshal(answer)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/invites/answer", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+type chatMessage struct {
+	Id       int       `json:"id"`
+	Username string    `json:"username"`
+	Message  string    `json:"message"`
+	Sent     time.Time `json:"sent"`
+}
+
+type chatMessages struct {
+	Messages []chatMessage `json:"messages"`
+}
+
+type sendChat struct {
+	Message string `json:"message"`
+}
+
+func (m requestHandler) chatRequest(room gameId, since int) []chatMessage {
+	requestURL := fmt.Sprintf("%s/chat?gameId=%s&since=%d", env.Server, room.GameId, since)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: ", res.StatusCode)
+		return nil
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return nil
+	}
+
+	var messages chatMessages
+	json.Unmarshal([]byte(body.String()), &messages)
+
+	return messages.Messages
+}
+
+func (m requestHandler) sendChatRequest(room gameId, chat sendChat) bool {
+	payload, _ := json.Marshal(chat)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/chat?gameId=%s", env.Server, room.GameId)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: %s\n", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: %d\n", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+type react struct {
+	Reaction string `json:"reaction"`
+}
+
+func (m requestHandler) reactRequest(react react) bool {
+	payload, _ := json.Marshal(react)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/react", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: %s\n", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: %d\n", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
+
+func (m requestHandler) keymapRequest() keymapConfig {
+	var km keymapConfig
+	requestURL := fmt.Sprintf("%s/keymap", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Get(requestURL)
+	if err != nil {
+		log.Error("error making http request: ", err)
+		return km
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return km
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, res.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("error making http request: %s\n", err.Error()))
+		return km
+	}
+
+	json.Unmarshal([]byte(body.String()), &km)
+
+	return km
+}
+
+func (m requestHandler) saveKeymapRequest(km keymapConfig) bool {
+	payload, _ := json.Marshal(km)
+	reader := bytes.NewReader(payload)
+	requestURL := fmt.Sprintf("%s/keymap", env.Server)
+
+	client := &http.Client{
+		Jar: m.jar,
+	}
+
+	res, err := client.Post(requestURL, "raw", reader)
+	if err != nil {
+		log.Error("error making http request: %s\n", err)
+		return false
+	}
+
+	if res.StatusCode != http.StatusOK {
+		log.Error("bad status making http request: %d\n", res.StatusCode)
+		return false
+	}
+
+	client.Jar.SetCookies(res.Request.URL, res.Cookies())
+
+	return true
+}
@@ -0,0 +1,71 @@
+package main
+
+import "fmt"
+
+// Brisca plays with the 40 card deck, the 8s and 9s are left out.
+var deckNumbers = []int{1, 2, 3, 4, 5, 6, 7, 10, 11, 12}
+
+func fullDeck() []card {
+	var deck []card
+	for _, suit := range SUITS {
+		for _, num := range deckNumbers {
+			deck = append(deck, newCard(fmt.Sprintf("%s:%d", suit, num)))
+		}
+	}
+	return deck
+}
+
+// cardId numbers the deck from 0 to 39, for card sets as bit masks.
+func cardId(c card) int {
+	suit := 0
+	for i, s := range SUITS {
+		if s == c.suitString {
+			suit = i
+		}
+	}
+	num := c.num
+	if num > 7 {
+		num -= 2
+	}
+	return suit*len(deckNumbers) + num - 1
+}
+
+// beats is whether c takes the trick from the winning card so far, the led
+// suit only matters when neither is a life card.
+func beats(c, winning card, lifeSuit string) bool {
+	switch {
+	case c.suitString == winning.suitString:
+		return c.val > winning.val
+	case c.suitString == lifeSuit:
+		return true
+	}
+	return false
+}
+
+// trickWinner is the position in the trick of the card that takes it.
+func trickWinner(trick []card, lifeSuit string) int {
+	winner := 0
+	for i := 1; i < len(trick); i++ {
+		if beats(trick[i], trick[winner], lifeSuit) {
+			winner = i
+		}
+	}
+	return winner
+}
+
+func trickPoints(trick []card) int {
+	points := 0
+	for _, c := range trick {
+		points += c.score
+	}
+	return points
+}
+
+// sideOf is the seat's team in 4 player games, everyone plays alone
+// otherwise.
+func sideOf(seat, players int) int {
+	if players == 4 {
+		return seat % 2
+	}
+	return seat
+}
@@ -39,6 +39,12 @@ type Environment struct {
 	Log    string `default:"brisca.log"`
 	Debug  bool   `default:"false"`
 	Key    string `default:""`
+	// Serve the extra endpoints, like chat, from memory instead of the server.
+	Offline bool `default:"false"`
+	// Directory with each account's finished games.
+	History string `default:"history"`
+	// Keys the daily deals, they can't be dealt ahead without it.
+	DailySecret string `default:""`
 }
 
 func main() {
@@ -57,7 +63,8 @@ func main() {
 		// This makes make PubKey Auth optional
 		wish.WithMiddleware(
 			bubbletea.Middleware(teaHandler),
-			activeterm.Middleware(), // Bubble Tea apps usually require a PTY.
+			activeterm.Middleware(),   // Bubble Tea apps usually require a PTY.
+			ReplayCommandMiddleware(), // Exec commands run without a PTY.
 			AuthMiddleware(),
 			logging.Middleware(),
 		),
@@ -117,8 +124,9 @@ func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
 	// your Bubble Tea model.
 	// renderer := bubbletea.MakeRenderer(s)
 
-	m := newModel(&s)
-	return m, []tea.ProgramOption{tea.WithAltScreen()}
+	presence := newPresenceTracker()
+	m := newModel(&s, presence)
+	return m, []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithFilter(presence.observe)}
 }
 
 func keyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
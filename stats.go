@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	ratingStart = 1200
+	ratingK     = 32
+)
+
+const (
+	resultWin  = "win"
+	resultLoss = "loss"
+	resultTie  = "tie"
+)
+
+// gameResult is how a finished game went for each seat, every player reports
+// it and the first report counts.
+type gameResult struct {
+	GameId      string         `json:"gameId"`
+	PlayerCount int            `json:"playerCount"`
+	Players     []resultPlayer `json:"players"`
+}
+
+type resultPlayer struct {
+	Username string `json:"username"`
+	Team     string `json:"team"` // Only for 4 player games
+	Points   int    `json:"points"`
+	Aces     int    `json:"aces"`
+	Swaps    int    `json:"swaps"`
+	Result   string `json:"result"`
+}
+
+type record struct {
+	Played int `json:"played"`
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Ties   int `json:"ties"`
+}
+
+type playerStats struct {
+	Username string `json:"username"`
+	// Elo, only 2 player and team games are rated.
+	Rating int `json:"rating"`
+	// By player count, "2", "3" and "4".
+	Records map[string]record `json:"records"`
+	Games   int               `json:"games"`
+	Points  int               `json:"points"`
+	Aces    int               `json:"aces"`
+	Swaps   int               `json:"swaps"`
+}
+
+// statsBackend is where results are kept, the game server or the offline
+// stand-in.
+type statsBackend interface {
+	statsRequest(username string) playerStats
+	reportResultRequest(result gameResult) bool
+}
+
+func (m userGlobal) statsBackend() statsBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
+
+func newPlayerStats(username string) playerStats {
+	return playerStats{
+		Username: username,
+		Rating:   ratingStart,
+		Records:  map[string]record{},
+	}
+}
+
+func (s playerStats) averagePoints() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Points) / float64(s.Games)
+}
+
+// add counts a game, the rating is updated separately by rate.
+func (s *playerStats) add(playerCount int, p resultPlayer) {
+	r := s.Records[fmt.Sprint(playerCount)]
+	r.Played++
+	switch p.Result {
+	case resultWin:
+		r.Wins++
+	case resultLoss:
+		r.Losses++
+	default:
+		r.Ties++
+	}
+	s.Records[fmt.Sprint(playerCount)] = r
+	s.Games++
+	s.Points += p.Points
+	s.Aces += p.Aces
+	s.Swaps += p.Swaps
+}
+
+// eloExpected is the chance of a rating a beating b.
+func eloExpected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// rate returns the new ratings of a game between two sides, a team plays as
+// its average rating and every member moves by the same amount.
+func rate(ratings map[string]int, result gameResult) map[string]int {
+	if result.PlayerCount == 3 {
+		return nil
+	}
+	sides := map[string][]resultPlayer{}
+	for _, p := range result.Players {
+		side := p.Username
+		if result.PlayerCount == 4 {
+			side = p.Team
+		}
+		sides[side] = append(sides[side], p)
+	}
+	if len(sides) != 2 {
+		return nil
+	}
+
+	average := map[string]float64{}
+	var names []string
+	for side, players := range sides {
+		for _, p := range players {
+			average[side] += float64(ratings[p.Username])
+		}
+		average[side] /= float64(len(players))
+		names = append(names, side)
+	}
+
+	updated := map[string]int{}
+	for i, side := range names {
+		other := names[1-i]
+		score := 0.5
+		switch sides[side][0].Result {
+		case resultWin:
+			score = 1
+		case resultLoss:
+			score = 0
+		}
+		delta := ratingK * (score - eloExpected(average[side], average[other]))
+		for _, p := range sides[side] {
+			updated[p.Username] = ratings[p.Username] + int(math.Round(delta))
+		}
+	}
+	return updated
+}
+
+// gameResult reads the result off the table, seats are in server order.
+func (m gsModel) gameResult(won gameWonPayload) gameResult {
+	result := gameResult{
+		GameId:      m.gameConfig.GameId,
+		PlayerCount: m.gameConfig.MaxPlayers,
+	}
+	for seat := range m.gameConfig.MaxPlayers {
+		player := m.playerSeats[seat]
+		p := resultPlayer{
+			Username: player.name,
+			Points:   player.score,
+			Swaps:    player.swaps,
+			Result:   resultLoss,
+		}
+		for _, c := range player.scorePile {
+			if c.num == 1 {
+				p.Aces++
+			}
+		}
+		if m.gameConfig.MaxPlayers == 4 {
+			p.Team = "A"
+			if seat%2 == 1 {
+				p.Team = "B"
+			}
+			switch won.Team {
+			case p.Team:
+				p.Result = resultWin
+			case "draw":
+				p.Result = resultTie
+			}
+		} else {
+			switch won.Seat {
+			case seat:
+				p.Result = resultWin
+			case -1:
+				p.Result = resultTie
+			}
+		}
+		result.Players = append(result.Players, p)
+	}
+	return result
+}
+
+func (m gsModel) reportResult(won gameWonPayload) tea.Cmd {
+	if m.replay || m.tutorial != nil || m.daily != nil {
+		return nil
+	}
+	result := m.gameResult(won)
+	return func() tea.Msg {
+		m.userGlobal.statsBackend().reportResultRequest(result)
+		return nil
+	}
+}
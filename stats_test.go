@@ -0,0 +1,92 @@
+package main
+
+import "testing"
+
+func TestRateEvenGame(t *testing.T) {
+	ratings := map[string]int{"ana": 1200, "bo": 1200}
+	result := gameResult{PlayerCount: 2, Players: []resultPlayer{
+		{Username: "ana", Result: resultWin},
+		{Username: "bo", Result: resultLoss},
+	}}
+	got := rate(ratings, result)
+	if got["ana"] != 1216 || got["bo"] != 1184 {
+		t.Errorf("got %v, want ana 1216 and bo 1184", got)
+	}
+
+	result.Players[0].Result, result.Players[1].Result = resultTie, resultTie
+	if got := rate(ratings, result); got["ana"] != 1200 || got["bo"] != 1200 {
+		t.Errorf("an even tie moved the ratings to %v", got)
+	}
+}
+
+func TestRateFavorite(t *testing.T) {
+	ratings := map[string]int{"ana": 1600, "bo": 1200}
+	result := gameResult{PlayerCount: 2, Players: []resultPlayer{
+		{Username: "ana", Result: resultWin},
+		{Username: "bo", Result: resultLoss},
+	}}
+	got := rate(ratings, result)
+	// Expected to win 10 to 1.
+	if got["ana"] != 1603 || got["bo"] != 1197 {
+		t.Errorf("got %v, want ana 1603 and bo 1197", got)
+	}
+}
+
+func TestRateTeams(t *testing.T) {
+	ratings := map[string]int{"ana": 1300, "bo": 1100, "cy": 1200, "dan": 1200}
+	result := gameResult{PlayerCount: 4, Players: []resultPlayer{
+		{Username: "ana", Team: "A", Result: resultLoss},
+		{Username: "cy", Team: "B", Result: resultWin},
+		{Username: "bo", Team: "A", Result: resultLoss},
+		{Username: "dan", Team: "B", Result: resultWin},
+	}}
+	got := rate(ratings, result)
+	// Both teams average 1200, every member moves by the same amount.
+	want := map[string]int{"ana": 1284, "bo": 1084, "cy": 1216, "dan": 1216}
+	for username, rating := range want {
+		if got[username] != rating {
+			t.Errorf("%s is %d, want %d", username, got[username], rating)
+		}
+	}
+}
+
+func TestRateUnrated(t *testing.T) {
+	three := gameResult{PlayerCount: 3, Players: []resultPlayer{
+		{Username: "ana", Result: resultWin},
+		{Username: "bo", Result: resultLoss},
+		{Username: "cy", Result: resultLoss},
+	}}
+	if got := rate(map[string]int{}, three); got != nil {
+		t.Errorf("a 3 player game was rated %v", got)
+	}
+	alone := gameResult{PlayerCount: 2, Players: []resultPlayer{{Username: "ana", Result: resultWin}}}
+	if got := rate(map[string]int{}, alone); got != nil {
+		t.Errorf("a game with one side was rated %v", got)
+	}
+}
+
+func TestOfflineStats(t *testing.T) {
+	s := sessions("ana", "bo")
+	ana, bo := s[0], s[1]
+	result := gameResult{GameId: "g1", PlayerCount: 2, Players: []resultPlayer{
+		{Username: "ana", Points: 70, Aces: 3, Swaps: 1, Result: resultWin},
+		{Username: "bo", Points: 50, Aces: 1, Result: resultLoss},
+	}}
+	// Every player reports the game, the first report counts.
+	ana.reportResultRequest(result)
+	bo.reportResultRequest(result)
+
+	stats := bo.statsRequest("ana")
+	if stats.Games != 1 || stats.Points != 70 || stats.Aces != 3 || stats.Swaps != 1 {
+		t.Errorf("ana's stats are %+v", stats)
+	}
+	if r := stats.Records["2"]; r != (record{Played: 1, Wins: 1}) {
+		t.Errorf("ana's 2 player record is %+v", r)
+	}
+	if stats.Rating != 1216 {
+		t.Errorf("ana's rating is %d, want 1216", stats.Rating)
+	}
+	if got := ana.statsRequest("cy"); got.Rating != ratingStart || got.Games != 0 {
+		t.Errorf("a new player's stats are %+v", got)
+	}
+}
@@ -25,11 +25,11 @@ type statusBarModel struct {
 	canSwap     bool
 
 	// config
-	mySeat         int
-	swapBottomCard bool
-	maxPlayers     int
-	swapCard       card
-	renderEmoji    bool
+	mySeat      int
+	swapRules   []swapRule
+	maxPlayers  int
+	swapCard    card
+	renderEmoji bool
 }
 
 func (m statusBarModel) haventPlayed() bool {
@@ -72,7 +72,7 @@ func (m statusBarModel) Update(msg tea.Msg) (statusBarModel, tea.Cmd) {
 		cmds = append(cmds, m.timer.Init())
 	case gameConfigPayload:
 		m.maxPlayers = msg.MaxPlayers
-		m.swapBottomCard = msg.SwapBottomCard
+		m.swapRules = msg.swapRules()
 	case turnSwitchPayload:
 		if m.maxPlayers == 0 {
 			errMsg := "m.maxPlayers must be set before " +
@@ -123,7 +123,7 @@ func (m *statusBarModel) View(hand []card) string {
 		}
 
 		swapCardStatus := ""
-		if m.swapBottomCard && m.turn == m.mySeat && m.canSwap {
+		if len(m.swapRules) > 0 && m.turn == m.mySeat && m.canSwap {
 			swapCardStatus = ", you can swap " + m.swapCard.renderCard(m.renderEmoji) + " for the life card"
 		}
 
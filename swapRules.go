@@ -0,0 +1,76 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/huh"
+)
+
+// swapRule lets a card of the life suit take the life card's place, the
+// player picks up the life card.
+type swapRule struct {
+	Num int `json:"num"`
+	// Only when the life card is worth points, an ace, a three or a figure.
+	HighOnly bool `json:"highOnly"`
+}
+
+type swapPreset struct {
+	name        string
+	description string
+	rules       []swapRule
+}
+
+var swapPresets = []swapPreset{
+	{"none", "The life card stays put", nil},
+	{"two", "The 2 swaps any life card", []swapRule{{Num: 2}}},
+	{"seven", "The 2 swaps any life card, the 7 a high one", []swapRule{{Num: 2}, {Num: 7, HighOnly: true}}},
+}
+
+func findSwapPreset(name string) swapPreset {
+	i := slices.IndexFunc(swapPresets, func(p swapPreset) bool { return p.name == name })
+	if i == -1 {
+		return swapPresets[0]
+	}
+	return swapPresets[i]
+}
+
+func swapPresetOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, p := range swapPresets {
+		options = append(options, huh.NewOption(p.name+": "+p.description, p.name))
+	}
+	return options
+}
+
+func (r swapRule) allows(lifeCard card) bool {
+	return !r.HighOnly || lifeCard.score > 0
+}
+
+// swapRules falls back to the original house rule, only the 2 swaps, for
+// servers that don't send a rule set.
+func (gc gameConfigPayload) swapRules() []swapRule {
+	if len(gc.SwapRules) > 0 {
+		return gc.SwapRules
+	}
+	if gc.SwapBottomCard {
+		return swapPresets[1].rules
+	}
+	return nil
+}
+
+// swapCandidate is the card in hand that may take the life card's place, the
+// first rule that matches wins.
+func swapCandidate(rules []swapRule, lifeCard card, hand []card) (card, bool) {
+	for _, rule := range rules {
+		if !rule.allows(lifeCard) {
+			continue
+		}
+		i := slices.IndexFunc(hand, func(c card) bool {
+			return c.num == rule.Num && c.suitString == lifeCard.suitString
+		})
+		if i != -1 {
+			return hand[i], true
+		}
+	}
+	return card{}, false
+}
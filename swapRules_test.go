@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestSwapCandidate(t *testing.T) {
+	seven := findSwapPreset("seven").rules
+	tests := []struct {
+		name  string
+		rules []swapRule
+		life  string
+		hand  []string
+		want  string // Empty when no card may swap
+	}{
+		{"the 2 swaps a low life card", seven, "ORO:4", []string{"COPA:1", "ORO:2"}, "ORO:2"},
+		{"the 7 can't swap a low life card", seven, "ORO:4", []string{"ORO:7", "COPA:2"}, ""},
+		{"the 7 swaps a high life card", seven, "ORO:12", []string{"BASTO:3", "ORO:7"}, "ORO:7"},
+		{"the 2 goes first", seven, "ORO:1", []string{"ORO:7", "ORO:2"}, "ORO:2"},
+		{"only the life suit swaps", seven, "ORO:1", []string{"COPA:2", "ESPADA:7"}, ""},
+		{"no rules", findSwapPreset("none").rules, "ORO:1", []string{"ORO:2"}, ""},
+	}
+	for _, tt := range tests {
+		var hand []card
+		for _, c := range tt.hand {
+			hand = append(hand, newCard(c))
+		}
+		got, ok := swapCandidate(tt.rules, newCard(tt.life), hand)
+		switch {
+		case tt.want == "" && ok:
+			t.Errorf("%s: %s may swap", tt.name, got.cardString())
+		case tt.want != "" && (!ok || got.cardString() != tt.want):
+			t.Errorf("%s: got %s %v, want %s", tt.name, got.cardString(), ok, tt.want)
+		}
+	}
+}
+
+func TestSwapRulesFallback(t *testing.T) {
+	if rules := (gameConfigPayload{}).swapRules(); len(rules) != 0 {
+		t.Errorf("a game without swaps has %v", rules)
+	}
+	// The original house rule, before the server sent a rule set.
+	rules := gameConfigPayload{SwapBottomCard: true}.swapRules()
+	if len(rules) != 1 || rules[0] != (swapRule{Num: 2}) {
+		t.Errorf("swapBottomCard has %v, want only the 2", rules)
+	}
+	seven := findSwapPreset("seven").rules
+	if rules := (gameConfigPayload{SwapBottomCard: true, SwapRules: seven}).swapRules(); len(rules) != 2 {
+		t.Errorf("the server's rules were replaced with %v", rules)
+	}
+}
+
+func TestNewBottomCard(t *testing.T) {
+	life := newCard("ESPADA:3")
+	if c := newBottomCard(life, ""); c.cardString() != "ESPADA:2" {
+		t.Errorf("an old server's swap left %s, want the 2", c.cardString())
+	}
+	if c := newBottomCard(life, "ESPADA:7"); c.cardString() != "ESPADA:7" {
+		t.Errorf("the swap left %s, want the 7", c.cardString())
+	}
+}
@@ -20,6 +20,7 @@ type tableModel struct {
 
 	bottomCardStyle lipgloss.Style
 	renderEmoji     bool
+	largeCards      bool
 }
 
 func newTableModel(renderEmoji bool) tableModel {
@@ -51,6 +52,9 @@ func (tm tableModel) Update(msg tea.Msg) (tableModel, tea.Cmd) {
 }
 
 func (tm tableModel) renderCardsInPlay(width int, height int) string {
+	if tm.largeCards {
+		return tm.renderLargeCardsInPlay()
+	}
 	if height <= 2 {
 		cip := ""
 		switch {
@@ -91,6 +95,16 @@ rowLoop:
 	return cip
 }
 
+// renderLargeCardsInPlay lays the cards in play side by side, the size check
+// is done beforehand by gsModel.updateWindow.
+func (tm tableModel) renderLargeCardsInPlay() string {
+	cip := []string{"  "}
+	for i := range tm.cardsInPlay {
+		cip = append(cip, tm.cardsInPlay[i].renderLargeCard(tm.renderEmoji, largeCardStyle))
+	}
+	return "\n" + lipgloss.JoinHorizontal(lipgloss.Top, cip...)
+}
+
 func (tm tableModel) View(width int, height int) string {
 	return fmt.Sprintf("Table:\n  Deck: %d\n  Life Card: %s\n  In Play: %s",
 		tm.deckSize,
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	formatSingleElimination = "single"
+	formatRoundRobin        = "roundRobin"
+)
+
+// tournament is a bracket of two player games, the server makes a private
+// game for each pairing and advances the winners.
+type tournament struct {
+	Id      string      `json:"id"`
+	Name    string      `json:"name"`
+	Format  string      `json:"format"`
+	Owner   string      `json:"owner"`
+	Players []string    `json:"players"`
+	Started bool        `json:"started"`
+	Rounds  [][]pairing `json:"rounds"`
+	Winner  string      `json:"winner"`
+}
+
+// pairing is a game of the bracket, a pairing with a single player is a bye.
+type pairing struct {
+	Players []string `json:"players"`
+	GameId  string   `json:"gameId"`
+	Winner  string   `json:"winner"`
+}
+
+type newTournament struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+}
+
+type tournamentResult struct {
+	GameId string `json:"gameId"`
+	Winner string `json:"winner"` // Empty on a tie
+}
+
+// tournamentGame makes a pairing's private game, once made the other
+// player joins it by the id.
+type tournamentGame struct {
+	Round   int    `json:"round"`
+	Pairing int    `json:"pairing"`
+	GameId  string `json:"gameId"`
+}
+
+// tournamentBackend is where tournaments are kept, the game server or the
+// offline stand-in.
+type tournamentBackend interface {
+	tournamentsRequest() []tournament
+	tournamentRequest(id string) tournament
+	makeTournamentRequest(nt newTournament) tournament
+	registerTournamentRequest(id string) bool
+	startTournamentRequest(id string) bool
+	tournamentGameRequest(id string, game tournamentGame) bool
+	reportTournamentRequest(id string, result tournamentResult) bool
+}
+
+func (m userGlobal) tournamentBackend() tournamentBackend {
+	if env.Offline {
+		return m.offlineSession()
+	}
+	return m.rh
+}
+
+func formatName(format string) string {
+	if format == formatRoundRobin {
+		return "round robin"
+	}
+	return "single elimination"
+}
+
+// start draws the first round, or every round for round robin.
+func (t *tournament) start() {
+	t.Started = true
+	if t.Format == formatRoundRobin {
+		t.Rounds = roundRobinRounds(t.Players)
+	} else {
+		t.Rounds = [][]pairing{eliminationRound(t.Players)}
+	}
+	t.advance()
+}
+
+// eliminationRound pairs the players in order, the odd one out gets a bye.
+func eliminationRound(players []string) []pairing {
+	var round []pairing
+	for i := 0; i < len(players); i += 2 {
+		if i+1 == len(players) {
+			round = append(round, pairing{Players: []string{players[i]}, Winner: players[i]})
+			continue
+		}
+		round = append(round, pairing{Players: slices.Clone(players[i : i+2])})
+	}
+	return round
+}
+
+// roundRobinRounds uses the circle method, one player stays put and the rest
+// rotate, so everyone meets everyone once.
+func roundRobinRounds(players []string) [][]pairing {
+	circle := slices.Clone(players)
+	if len(circle)%2 == 1 {
+		circle = append(circle, "") // Bye
+	}
+	n := len(circle)
+	var rounds [][]pairing
+	for range n - 1 {
+		var round []pairing
+		for i := range n / 2 {
+			a, b := circle[i], circle[n-1-i]
+			switch {
+			case a == "":
+				round = append(round, pairing{Players: []string{b}, Winner: b})
+			case b == "":
+				round = append(round, pairing{Players: []string{a}, Winner: a})
+			default:
+				round = append(round, pairing{Players: []string{a, b}})
+			}
+		}
+		rounds = append(rounds, round)
+		rotated := []string{circle[0], circle[n-1]}
+		circle = append(rotated, circle[1:n-1]...)
+	}
+	return rounds
+}
+
+// report records a game's winner, a tie is replayed in a new game.
+func (t *tournament) report(result tournamentResult) {
+	for r := range t.Rounds {
+		for p := range t.Rounds[r] {
+			pairing := &t.Rounds[r][p]
+			if pairing.GameId != result.GameId || pairing.Winner != "" {
+				continue
+			}
+			if result.Winner == "" {
+				pairing.GameId = ""
+			} else if slices.Contains(pairing.Players, result.Winner) {
+				pairing.Winner = result.Winner
+			}
+		}
+	}
+	t.advance()
+}
+
+// advance draws the next elimination round or crowns the winner once every
+// game of the last round is played.
+func (t *tournament) advance() {
+	if !t.Started || t.Winner != "" || len(t.Rounds) == 0 {
+		return
+	}
+	for _, round := range t.Rounds {
+		for _, pairing := range round {
+			if pairing.Winner == "" {
+				return
+			}
+		}
+	}
+
+	if t.Format == formatRoundRobin {
+		standings := t.standings()
+		if len(standings) > 0 {
+			t.Winner = standings[0].Player
+		}
+		return
+	}
+
+	var winners []string
+	for _, pairing := range t.Rounds[len(t.Rounds)-1] {
+		winners = append(winners, pairing.Winner)
+	}
+	if len(winners) == 1 {
+		t.Winner = winners[0]
+		return
+	}
+	t.Rounds = append(t.Rounds, eliminationRound(winners))
+	t.advance()
+}
+
+type standing struct {
+	Player string
+	Wins   int
+}
+
+// standings counts the games won, byes are not counted.
+func (t tournament) standings() []standing {
+	wins := map[string]int{}
+	for _, player := range t.Players {
+		wins[player] = 0
+	}
+	for _, round := range t.Rounds {
+		for _, pairing := range round {
+			if pairing.Winner != "" && len(pairing.Players) == 2 {
+				wins[pairing.Winner]++
+			}
+		}
+	}
+	var standings []standing
+	for player, n := range wins {
+		standings = append(standings, standing{player, n})
+	}
+	slices.SortFunc(standings, func(a, b standing) int {
+		return cmp.Or(b.Wins-a.Wins, cmp.Compare(a.Player, b.Player))
+	})
+	return standings
+}
+
+// myPairing is the unplayed game of the player, if any.
+func (t tournament) myPairing(username string) (round int, index int, ok bool) {
+	for r, pairings := range t.Rounds {
+		for p, pairing := range pairings {
+			if pairing.Winner == "" && len(pairing.Players) == 2 && slices.Contains(pairing.Players, username) {
+				return r, p, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (t tournament) clone() tournament {
+	t.Players = slices.Clone(t.Players)
+	rounds := make([][]pairing, len(t.Rounds))
+	for r := range t.Rounds {
+		for _, pairing := range t.Rounds[r] {
+			pairing.Players = slices.Clone(pairing.Players)
+			rounds[r] = append(rounds[r], pairing)
+		}
+	}
+	t.Rounds = rounds
+	return t
+}
+
+func (t tournament) Title() string { return t.Name }
+func (t tournament) Description() string {
+	state := "open, " + fmt.Sprint(len(t.Players)) + " registered"
+	switch {
+	case t.Winner != "":
+		state = "won by " + t.Winner
+	case t.Started:
+		state = "playing, " + fmt.Sprint(len(t.Players)) + " players"
+	}
+	return formatName(t.Format) + ", " + state
+}
+func (t tournament) FilterValue() string { return t.Name }
+
+// reportTournament advances the bracket, both players report and the first
+// report wins.
+func (m gsModel) reportTournament(won gameWonPayload) tea.Cmd {
+	if m.gameConfig.Tournament == "" || m.replay {
+		return nil
+	}
+	result := tournamentResult{GameId: m.gameConfig.GameId}
+	if won.Seat >= 0 {
+		result.Winner = m.playerSeats[won.Seat].name
+	}
+	id := m.gameConfig.Tournament
+	return func() tea.Msg {
+		m.userGlobal.tournamentBackend().reportTournamentRequest(id, result)
+		return nil
+	}
+}
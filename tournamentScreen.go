@@ -0,0 +1,415 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	TOURNAMENT_POLL_INTERVAL = time.Second * 2
+
+	pairingStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(inactiveColor).
+			Width(24)
+	myPairingStyle = pairingStyle.
+			BorderForeground(activeColor)
+	pairingWinnerStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("10"))
+	pairingLoserStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("241"))
+)
+
+type tournamentKeyMap struct {
+	New      key.Binding
+	Open     key.Binding
+	Register key.Binding
+	Start    key.Binding
+	Play     key.Binding
+	Back     key.Binding
+	Quit     key.Binding
+}
+
+func (k tournamentKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Register, k.Start, k.Play, k.Back}
+}
+
+func (k tournamentKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp(), {k.Quit}}
+}
+
+// newTournamentKeyMap is the tournament list's, the bracket has its own.
+func newTournamentKeyMap(km keymapConfig) tournamentKeyMap {
+	return tournamentKeyMap{
+		New:  km.binding("tournaments.new"),
+		Open: km.binding("tournaments.open"),
+		Back: km.binding("tournaments.back"),
+		Quit: km.binding("tournaments.quit"),
+	}
+}
+
+func newBracketKeyMap(km keymapConfig) tournamentKeyMap {
+	return tournamentKeyMap{
+		Register: km.binding("bracket.register"),
+		Start:    km.binding("bracket.start"),
+		Play:     km.binding("bracket.play"),
+		Back:     km.binding("bracket.back"),
+		Quit:     km.binding("bracket.quit"),
+	}
+}
+
+type tournamentsMsg []tournament
+
+// tournamentsModel lists every tournament, anyone can browse the brackets.
+type tournamentsModel struct {
+	list       list.Model
+	keys       tournamentKeyMap
+	userGlobal userGlobal
+}
+
+func newTournaments(userGlobal userGlobal) tournamentsModel {
+	keys := newTournamentKeyMap(userGlobal.keymap)
+	tournamentList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	tournamentList.Styles.Title = titleStyle
+	tournamentList.Title = "Tournaments"
+	tournamentList.SetStatusBarItemName("tournament", "tournaments")
+	tournamentList.DisableQuitKeybindings()
+	tournamentList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.New, keys.Open, keys.Back}
+	}
+	tournamentList.AdditionalFullHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.New, keys.Open, keys.Back, keys.Quit}
+	}
+	return tournamentsModel{
+		list:       tournamentList,
+		keys:       keys,
+		userGlobal: userGlobal,
+	}
+}
+
+func (m tournamentsModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), m.poll(0))
+}
+
+func (m tournamentsModel) poll(wait time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(wait)
+		return tournamentsMsg(m.userGlobal.tournamentBackend().tournamentsRequest())
+	}
+}
+
+func (m tournamentsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		h, v := docStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v)
+
+	case tournamentsMsg:
+		var items []list.Item
+		for _, t := range msg {
+			items = append(items, t)
+		}
+		cmds = append(cmds, m.list.SetItems(items), m.poll(TOURNAMENT_POLL_INTERVAL))
+
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			lm := newLobby(m.userGlobal)
+			return lm, lm.Init()
+		case key.Matches(msg, m.keys.New):
+			nt := newMakeTournament(m, m.userGlobal)
+			return nt, nt.Init()
+		case key.Matches(msg, m.keys.Open):
+			if t, ok := m.list.SelectedItem().(tournament); ok {
+				bm := newBracket(m.userGlobal, t.Id)
+				return bm, bm.Init()
+			}
+		}
+	}
+
+	list, cmd := m.list.Update(msg)
+	m.list = list
+	cmds = append(cmds, cmd)
+	return m, tea.Batch(cmds...)
+}
+
+func (m tournamentsModel) View() string {
+	return docStyle.Render(m.list.View())
+}
+
+type makeTournamentModel struct {
+	form       *huh.Form
+	nextView   tea.Model
+	userGlobal userGlobal
+}
+
+func newMakeTournament(nv tea.Model, userGlobal userGlobal) makeTournamentModel {
+	return makeTournamentModel{
+		form: huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Key("name").
+					Title("Tournament name:").
+					Validate(func(name string) error {
+						if strings.TrimSpace(name) == "" {
+							return fmt.Errorf("the tournament needs a name")
+						}
+						return nil
+					}),
+
+				huh.NewSelect[string]().
+					Key("format").
+					Options(
+						huh.NewOption(formatName(formatSingleElimination), formatSingleElimination),
+						huh.NewOption(formatName(formatRoundRobin), formatRoundRobin),
+					).
+					Title("Format:"),
+			),
+		),
+		nextView:   nv,
+		userGlobal: userGlobal,
+	}
+}
+
+func (m makeTournamentModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+func (m makeTournamentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	switch m.form.State {
+	case huh.StateAborted:
+		return m.nextView, m.nextView.Init()
+	case huh.StateCompleted:
+		t := m.userGlobal.tournamentBackend().makeTournamentRequest(newTournament{
+			Name:   strings.TrimSpace(m.form.GetString("name")),
+			Format: m.form.GetString("format"),
+		})
+		if t.Id == "" {
+			return m.nextView, m.nextView.Init()
+		}
+		bm := newBracket(m.userGlobal, t.Id)
+		return bm, bm.Init()
+	}
+
+	return m, cmd
+}
+
+func (m makeTournamentModel) View() string {
+	return m.form.View()
+}
+
+type tournamentMsg tournament
+
+// bracketModel is the live view of one tournament.
+type bracketModel struct {
+	id         string
+	tournament tournament
+	notice     string
+	keys       tournamentKeyMap
+	help       help.Model
+	userGlobal userGlobal
+}
+
+func newBracket(userGlobal userGlobal, id string) bracketModel {
+	return bracketModel{
+		id:         id,
+		keys:       newBracketKeyMap(userGlobal.keymap),
+		help:       help.New(),
+		userGlobal: userGlobal,
+	}
+}
+
+func (m bracketModel) Init() tea.Cmd {
+	return tea.Batch(m.userGlobal.LastWindowSizeReplay(), m.poll(0))
+}
+
+func (m bracketModel) poll(wait time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(wait)
+		return tournamentMsg(m.userGlobal.tournamentBackend().tournamentRequest(m.id))
+	}
+}
+
+func (m bracketModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.userGlobal.sizeMsg = msg
+		m.help.Width = msg.Width
+
+	case tournamentMsg:
+		m.tournament = tournament(msg)
+		return m, m.poll(TOURNAMENT_POLL_INTERVAL)
+
+	case tea.KeyMsg:
+		backend := m.userGlobal.tournamentBackend()
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			tm := newTournaments(m.userGlobal)
+			return tm, tm.Init()
+		case key.Matches(msg, m.keys.Register):
+			if !backend.registerTournamentRequest(m.id) {
+				m.notice = "Could not register, the tournament may have started."
+				return m, nil
+			}
+			m.notice = "Registered!"
+			m.tournament = backend.tournamentRequest(m.id)
+		case key.Matches(msg, m.keys.Start):
+			if !backend.startTournamentRequest(m.id) {
+				m.notice = "Only the owner can start it, with at least 2 players."
+				return m, nil
+			}
+			m.notice = ""
+			m.tournament = backend.tournamentRequest(m.id)
+		case key.Matches(msg, m.keys.Play):
+			return m.play()
+		}
+	}
+	return m, nil
+}
+
+// play seats the player in their pairing's game, the first of the pair to
+// get there makes the private game and the other joins it.
+func (m bracketModel) play() (tea.Model, tea.Cmd) {
+	round, index, ok := m.tournament.myPairing(m.userGlobal.username)
+	if !ok {
+		m.notice = "You have no game to play right now."
+		return m, nil
+	}
+
+	game := gameId{GameId: m.tournament.Rounds[round][index].GameId}
+	if game.GameId == "" {
+		made := m.userGlobal.rh.makeGameRequest(gameConfig{
+			GameType:       "private",
+			MaxPlayers:     2,
+			SwapBottomCard: true,
+			Tournament:     m.id,
+		})
+		pairingGame := tournamentGame{Round: round, Pairing: index, GameId: made.GameId}
+		if made.GameId != "" && m.userGlobal.tournamentBackend().tournamentGameRequest(m.id, pairingGame) {
+			wrm := newWaitingRoom(m.userGlobal, gameId{GameId: made.GameId})
+			return wrm, wrm.Init()
+		}
+		// The other player got there first.
+		m.userGlobal.rh.leaveGameRequest()
+		m.tournament = m.userGlobal.tournamentBackend().tournamentRequest(m.id)
+		game.GameId = m.tournament.Rounds[round][index].GameId
+	}
+
+	if !m.userGlobal.rh.joinGameRequest(game) {
+		m.notice = "Could not join your game."
+		return m, nil
+	}
+	wrm := newWaitingRoom(m.userGlobal, game)
+	return wrm, wrm.Init()
+}
+
+func (m bracketModel) View() string {
+	t := m.tournament
+	if t.Id == "" {
+		return docStyle.Render(titleStyle.Render("Tournament") + "\n\nLoading...")
+	}
+
+	header := []string{
+		titleStyle.Render(t.Name),
+		"",
+		fmt.Sprintf("%s, run by %s", formatName(t.Format), t.Owner),
+	}
+	if t.Winner != "" {
+		header = append(header, pairingWinnerStyle.Render(t.Winner+" won the tournament!"))
+	}
+
+	var body string
+	switch {
+	case !t.Started:
+		body = "Registered:\n" + strings.Join(t.Players, "\n")
+	case t.Format == formatRoundRobin:
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.roundsView(), "  ", m.standingsView())
+	default:
+		body = m.roundsView()
+	}
+
+	s := lipgloss.JoinVertical(lipgloss.Left,
+		strings.Join(header, "\n"),
+		"",
+		body,
+		"",
+		m.notice,
+		m.help.View(m.keys),
+	)
+	return docStyle.Render(s)
+}
+
+// roundsView puts each round in a column, left to right.
+func (m bracketModel) roundsView() string {
+	var columns []string
+	for r, round := range m.tournament.Rounds {
+		title := fmt.Sprintf("Round %d", r+1)
+		if m.tournament.Format == formatSingleElimination && len(round) == 1 && m.tournament.Winner == "" {
+			title = "Final"
+		}
+		pairings := []string{panelTitleStyle.Render(title)}
+		for _, pairing := range round {
+			pairings = append(pairings, m.pairingView(pairing))
+		}
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, pairings...))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+func (m bracketModel) pairingView(p pairing) string {
+	style := pairingStyle
+	if slices.Contains(p.Players, m.userGlobal.username) && p.Winner == "" {
+		style = myPairingStyle
+	}
+	if len(p.Players) == 1 {
+		return style.Render(p.Players[0] + " (bye)")
+	}
+
+	var lines []string
+	for _, player := range p.Players {
+		switch p.Winner {
+		case "":
+			lines = append(lines, player)
+		case player:
+			lines = append(lines, pairingWinnerStyle.Render(player))
+		default:
+			lines = append(lines, pairingLoserStyle.Render(player))
+		}
+	}
+	if p.Winner == "" && p.GameId != "" {
+		lines = append(lines, pairingLoserStyle.Render("playing..."))
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func (m bracketModel) standingsView() string {
+	lines := []string{panelTitleStyle.Render("Standings")}
+	for i, standing := range m.tournament.standings() {
+		lines = append(lines, fmt.Sprintf("%d. %s %d", i+1, standing.Player, standing.Wins))
+	}
+	return strings.Join(lines, "\n")
+}
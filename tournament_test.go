@@ -0,0 +1,112 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOfflineTournamentRegisterAndStart(t *testing.T) {
+	s := sessions("ana", "bo", "cy")
+	ana, bo, cy := s[0], s[1], s[2]
+	id := ana.makeTournamentRequest(newTournament{Name: "Copa", Format: formatSingleElimination}).Id
+
+	if ana.registerTournamentRequest(id) {
+		t.Error("the owner registered twice")
+	}
+	if ana.startTournamentRequest(id) {
+		t.Error("started with a single player")
+	}
+	if !bo.registerTournamentRequest(id) || !cy.registerTournamentRequest(id) {
+		t.Fatal("could not register")
+	}
+	if bo.startTournamentRequest(id) {
+		t.Error("started by a player that is not the owner")
+	}
+	if !ana.startTournamentRequest(id) {
+		t.Fatal("the owner could not start")
+	}
+	if ana.startTournamentRequest(id) {
+		t.Error("started twice")
+	}
+	if (offlineSession{username: "dan", store: ana.store}).registerTournamentRequest(id) {
+		t.Error("registered after the start")
+	}
+}
+
+func TestOfflineTournamentElimination(t *testing.T) {
+	s := sessions("ana", "bo", "cy")
+	ana, bo, cy := s[0], s[1], s[2]
+	id := ana.makeTournamentRequest(newTournament{Name: "Copa", Format: formatSingleElimination}).Id
+	bo.registerTournamentRequest(id)
+	cy.registerTournamentRequest(id)
+	ana.startTournamentRequest(id)
+
+	round := ana.tournamentRequest(id).Rounds[0]
+	if len(round) != 2 || !slices.Equal(round[0].Players, []string{"ana", "bo"}) {
+		t.Fatalf("the first round is %v, want ana against bo", round)
+	}
+	if bye := round[1]; !slices.Equal(bye.Players, []string{"cy"}) || bye.Winner != "cy" {
+		t.Errorf("the odd player out got %v, want a bye", bye)
+	}
+
+	game := tournamentGame{Round: 0, Pairing: 0, GameId: "g1"}
+	if cy.tournamentGameRequest(id, game) {
+		t.Error("a player outside the pairing made its game")
+	}
+	if !bo.tournamentGameRequest(id, game) {
+		t.Fatal("could not make the pairing's game")
+	}
+	if ana.tournamentGameRequest(id, tournamentGame{Round: 0, Pairing: 0, GameId: "g2"}) {
+		t.Error("the pairing's game was made twice")
+	}
+
+	ana.reportTournamentRequest(id, tournamentResult{GameId: "g1"})
+	if tm := ana.tournamentRequest(id); tm.Rounds[0][0].GameId != "" || len(tm.Rounds) != 1 {
+		t.Errorf("a tie wasn't replayed: %v", tm.Rounds)
+	}
+	bo.tournamentGameRequest(id, tournamentGame{Round: 0, Pairing: 0, GameId: "g3"})
+	bo.reportTournamentRequest(id, tournamentResult{GameId: "g3", Winner: "bo"})
+	tm := ana.tournamentRequest(id)
+	if len(tm.Rounds) != 2 || !slices.Equal(tm.Rounds[1][0].Players, []string{"bo", "cy"}) {
+		t.Fatalf("the final is %v, want bo against cy", tm.Rounds)
+	}
+
+	bo.tournamentGameRequest(id, tournamentGame{Round: 1, Pairing: 0, GameId: "g4"})
+	cy.reportTournamentRequest(id, tournamentResult{GameId: "g4", Winner: "cy"})
+	if winner := ana.tournamentRequest(id).Winner; winner != "cy" {
+		t.Errorf("the winner is %q, want cy", winner)
+	}
+}
+
+func TestOfflineTournamentRoundRobin(t *testing.T) {
+	s := sessions("ana", "bo", "cy")
+	ana := s[0]
+	id := ana.makeTournamentRequest(newTournament{Name: "Liga", Format: formatRoundRobin}).Id
+	s[1].registerTournamentRequest(id)
+	s[2].registerTournamentRequest(id)
+	ana.startTournamentRequest(id)
+
+	met := map[string]int{}
+	byes := map[string]int{}
+	for _, round := range ana.tournamentRequest(id).Rounds {
+		for _, p := range round {
+			if len(p.Players) == 1 {
+				byes[p.Players[0]]++
+				continue
+			}
+			pair := slices.Sorted(slices.Values(p.Players))
+			met[strings.Join(pair, " ")]++
+		}
+	}
+	for _, pair := range []string{"ana bo", "ana cy", "bo cy"} {
+		if met[pair] != 1 {
+			t.Errorf("%s met %d times, want once", pair, met[pair])
+		}
+	}
+	for _, player := range []string{"ana", "bo", "cy"} {
+		if byes[player] != 1 {
+			t.Errorf("%s got %d byes, want 1", player, byes[player])
+		}
+	}
+}
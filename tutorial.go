@@ -0,0 +1,169 @@
+package main
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	tutorialGameId = "tutorial"
+	tutorialBot    = "Tutor"
+)
+
+var (
+	tutorialPromptStyle = lipgloss.NewStyle().
+				BorderStyle(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("69")).
+				Padding(0, 1)
+	tutorialBlockedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("9"))
+)
+
+// tutorialAnchor is the box a prompt points at.
+type tutorialAnchor int
+
+const (
+	anchorTable tutorialAnchor = iota
+	anchorOpponent
+	anchorHand
+)
+
+// tutorialStep is a prompt for one of the player's turns, it passes once the
+// player makes the move it asks for. Keys are written as {swap}, the player's
+// binding takes their place.
+type tutorialStep struct {
+	prompt string
+	anchor tutorialAnchor
+	// The card to play, or the swap.
+	card string
+	swap bool
+	// Why the other cards are refused, a generic reason otherwise.
+	refusals map[string]string
+}
+
+// The deal is fixed until the steps are done: Oro is the life suit, the
+// player leads and holds the 2 of Oro for the swap.
+var (
+	tutorialLife  = "ORO:7"
+	tutorialHands = [][]string{
+		{"BASTO:4", "COPA:3", "ORO:2"},
+		{"BASTO:12", "COPA:1", "ESPADA:5"},
+	}
+	// The first draws, the rest of the deck is shuffled with a fixed seed.
+	tutorialDraws = []string{"COPA:12", "ORO:4", "BASTO:1", "ESPADA:6"}
+	// Tutor's first cards, the playout policy plays the rest.
+	tutorialBotCards = []string{"BASTO:12", "COPA:1"}
+
+	tutorialSteps = []tutorialStep{
+		{
+			prompt: "The card under the deck is the life card, so Oro is the life suit this game. " +
+				"You lead the first trick: play the 4 of Basto, it's worth no points.",
+			anchor: anchorTable,
+			card:   "BASTO:4",
+			refusals: map[string]string{
+				"COPA:3": "The 3 of Copa is worth 10 points, don't lead it into an unknown hand.",
+				"ORO:2":  "Keep the 2 of Oro, it will take the life card later.",
+			},
+		},
+		{
+			prompt: "Tutor's 12 beat your 4: with no life cards on the table the highest card of the suit led wins. " +
+				"The winner draws first and leads. Tutor led the ace of Copa, take it with the 4 of Oro: " +
+				"a life card beats any card of the other suits.",
+			anchor: anchorOpponent,
+			card:   "ORO:4",
+			refusals: map[string]string{
+				"COPA:3": "The 3 is below the ace in Copa, Tutor would take 21 points.",
+				"ORO:2":  "The 2 of Oro would win too, but keep it for the swap.",
+			},
+		},
+		{
+			prompt: "That was 11 points: aces are worth 11, 3s 10, 12s 4, 11s 3 and 10s 2, the rest nothing, " +
+				"61 of the 120 points win. You won, so you drew first and lead now. " +
+				"The 2 of the life suit can take the life card: press {swap} to swap.",
+			anchor: anchorHand,
+			swap:   true,
+			refusals: map[string]string{
+				"": "Swap first: press {swap} to trade your 2 of Oro for the 7 under the deck.",
+			},
+		},
+		{
+			prompt: "The 7 of Oro is yours and the 2 will be the last card drawn. " +
+				"That's all the rules, play the rest of the game your way.",
+			anchor: anchorHand,
+		},
+	}
+)
+
+func newTutorialEngine(username string) *localEngine {
+	dealt := slices.Concat(tutorialHands[0], tutorialHands[1], tutorialDraws, []string{tutorialLife})
+	var rest []card
+	for _, c := range fullDeck() {
+		if !slices.Contains(dealt, c.cardString()) {
+			rest = append(rest, c)
+		}
+	}
+	rng := rand.New(rand.NewPCG(7, 7)) // The same deal every time
+	rng.Shuffle(len(rest), func(i, j int) {
+		rest[i], rest[j] = rest[j], rest[i]
+	})
+
+	e := newLocalEngine(gameConfigPayload{
+		GameId:     tutorialGameId,
+		MaxPlayers: 2,
+		Variant:    variants[0].name,
+		SwapRules:  []swapRule{{Num: 2}},
+	}, username, tutorialBot,
+		[2][]card{handFromStrings(tutorialHands[0]), handFromStrings(tutorialHands[1])},
+		slices.Concat(handFromStrings(tutorialDraws), rest, []card{newCard(tutorialLife)}))
+	e.steps = tutorialSteps
+	e.botCards = tutorialBotCards
+	return e
+}
+
+// prompt is the current step's text and where it points, with the reason
+// the last move was refused.
+func (e *localEngine) prompt() (step *tutorialStep, blocked string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.currentStep(), e.blocked
+}
+
+func newTutorialGSModel(userGlobal userGlobal) gsModel {
+	m := newGSModel(userGlobal)
+	m.tutorial = newTutorialEngine(userGlobal.username)
+	m.backend = m.tutorial
+	return m
+}
+
+// tutorialView is the prompt, only on the player's turn so it doesn't
+// explain a trick before it is seen.
+func (m gsModel) tutorialView(width int) string {
+	step, blocked := m.tutorial.prompt()
+	if step == nil || !m.statusBar.isMyTurn() || !m.statusBar.haventPlayed() {
+		return ""
+	}
+	keys := strings.NewReplacer("{swap}", m.help.keys.Swap.Help().Key)
+	s := keys.Replace(step.prompt)
+	if blocked != "" {
+		s += "\n\n" + tutorialBlockedStyle.Render(keys.Replace(blocked))
+	}
+	return tutorialPromptStyle.Width(max(width-2, 10)).Render(s)
+}
+
+// tutorialBox is the empty box next to the anchor, where the prompt goes.
+func (m gsModel) tutorialBox() (int, int) {
+	step, _ := m.tutorial.prompt()
+	if step == nil {
+		return 2, 0
+	}
+	switch step.anchor {
+	case anchorTable:
+		return 1, 0
+	case anchorOpponent:
+		return 0, 0
+	}
+	return 2, 0
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/huh"
+)
+
+// variant is a way of playing brisca, the server deals it and the client
+// adapts the hand, the play keys and the deck count to it.
+type variant struct {
+	name        string
+	description string
+	handSize    int
+	// Your own hand is dealt face down, a card is only seen once played.
+	blind bool
+}
+
+// maxHandSize is the biggest hand of all the variants, there is a play key
+// for each card.
+const maxHandSize = 5
+
+var variants = []variant{
+	{"classic", "Three cards in hand", 3, false},
+	{"five", "Five cards in hand", 5, false},
+	{"ciega", "Brisca ciega, your hand is face down", 3, true},
+}
+
+// findVariant falls back to classic, older servers don't send a variant.
+func findVariant(name string) variant {
+	i := slices.IndexFunc(variants, func(v variant) bool { return v.name == name })
+	if i == -1 {
+		return variants[0]
+	}
+	return variants[i]
+}
+
+func variantOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, v := range variants {
+		options = append(options, huh.NewOption(v.name+": "+v.description, v.name))
+	}
+	return options
+}
+
+func (gc gameConfigPayload) variant() variant {
+	return findVariant(gc.Variant)
+}
+
+// renderHandCard hides the card when the variant is played blind.
+func (m gsModel) renderHandCard(c card) string {
+	if m.gameConfig.variant().blind {
+		return renderCardBack()
+	}
+	return c.renderCard(m.userGlobal.renderEmoji)
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestFindVariant(t *testing.T) {
+	// Older servers don't send a variant.
+	for _, name := range []string{"", "unknown"} {
+		if v := findVariant(name); v.name != "classic" || v.handSize != 3 {
+			t.Errorf("findVariant(%q) is %s with %d cards, want classic with 3", name, v.name, v.handSize)
+		}
+	}
+	if v := findVariant("five"); v.handSize != 5 || v.blind {
+		t.Errorf("five is %+v", v)
+	}
+	if v := findVariant("ciega"); v.handSize != 3 || !v.blind {
+		t.Errorf("ciega is %+v", v)
+	}
+}
+
+func TestVariantPlayKeys(t *testing.T) {
+	keys := newGameScreenKeyMap(keymapConfig{})
+	for _, v := range variants {
+		if v.handSize > maxHandSize || v.handSize > len(keys.Play) {
+			t.Errorf("%s deals %d cards, there are %d play keys", v.name, v.handSize, len(keys.Play))
+		}
+	}
+}
+
+func TestBlindSwapCheck(t *testing.T) {
+	for _, v := range variants {
+		m := gsModel{}
+		m.gameConfig = gameConfigPayload{MaxPlayers: 2, SwapBottomCard: true, Variant: v.name}
+		m.table.deckSize = 20
+		m.table.bottomCard = newCard("ORO:7")
+		m.statusBar.swapCard = newCard("ORO:2")
+		m.hand = []card{newCard("COPA:1"), newCard("ORO:2"), newCard("BASTO:5")}
+		m.swapCheck()
+		// Playing blind you can't know you hold the swap card.
+		if m.statusBar.canSwap == v.blind {
+			t.Errorf("%s: canSwap is %v", v.name, m.statusBar.canSwap)
+		}
+	}
+}
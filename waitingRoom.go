@@ -6,6 +6,8 @@ package main
 import (
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,6 +16,7 @@ import (
 
 const (
 	wrUpdateInterval = time.Duration(time.Millisecond * 200)
+	wrChatHeight     = 8 // 6 lines of chat and 2 borders
 )
 
 type wrKeyMap struct {
@@ -22,35 +25,23 @@ type wrKeyMap struct {
 	changeTeam key.Binding
 	leave      key.Binding
 	spectate   key.Binding
+	talk       key.Binding
+	profile    key.Binding
+	invite     key.Binding
 	quit       key.Binding
 }
 
-func newWrKeyMap() *wrKeyMap {
+func newWrKeyMap(km keymapConfig) *wrKeyMap {
 	return &wrKeyMap{
-		ready: key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "ready"),
-		),
-		start: key.NewBinding(
-			key.WithKeys("s"),
-			key.WithHelp("s", "start"),
-		),
-		changeTeam: key.NewBinding(
-			key.WithKeys("c"),
-			key.WithHelp("c", "change team"),
-		),
-		leave: key.NewBinding(
-			key.WithKeys("l"),
-			key.WithHelp("l", "leave"),
-		),
-		spectate: key.NewBinding(
-			key.WithKeys("w"),
-			key.WithHelp("w", "spectate"),
-		),
-		quit: key.NewBinding(
-			key.WithKeys("ctrl-c"),
-			key.WithHelp("ctrl-c", "quit"),
-		),
+		ready:      km.binding("waitingRoom.ready"),
+		start:      km.binding("waitingRoom.start"),
+		changeTeam: km.binding("waitingRoom.changeTeam"),
+		leave:      km.binding("waitingRoom.leave"),
+		spectate:   km.binding("waitingRoom.spectate"),
+		talk:       km.binding("waitingRoom.talk"),
+		profile:    km.binding("waitingRoom.profile"),
+		invite:     km.binding("waitingRoom.invite"),
+		quit:       km.binding("waitingRoom.quit"),
 	}
 }
 
@@ -61,11 +52,13 @@ type waitingRoomModel struct {
 	descDelegate   list.DefaultDelegate
 	noDescDelegate list.DefaultDelegate
 	userGlobal     userGlobal
+	gameId         gameId
+	chat           chatModel
 }
 
-func newWaitingRoom(userGlobal userGlobal) waitingRoomModel {
+func newWaitingRoom(userGlobal userGlobal, gameId gameId) waitingRoomModel {
 	var (
-		listKeys = newWrKeyMap()
+		listKeys = newWrKeyMap(userGlobal.keymap)
 	)
 	noDescDelegate := list.NewDefaultDelegate()
 	noDescDelegate.ShowDescription = false
@@ -78,6 +71,8 @@ func newWaitingRoom(userGlobal userGlobal) waitingRoomModel {
 		descDelegate:   descDelegate,
 		noDescDelegate: noDescDelegate,
 		userGlobal:     userGlobal,
+		gameId:         gameId,
+		chat:           newChat(userGlobal.chatBackend(), listKeys.talk),
 	}
 
 	wrm.list.AdditionalFullHelpKeys = func() []key.Binding {
@@ -87,6 +82,10 @@ func newWaitingRoom(userGlobal userGlobal) waitingRoomModel {
 			listKeys.changeTeam,
 			listKeys.leave,
 			listKeys.spectate,
+			listKeys.talk,
+			listKeys.profile,
+			listKeys.invite,
+			listKeys.quit,
 		}
 	}
 
@@ -96,9 +95,11 @@ func newWaitingRoom(userGlobal userGlobal) waitingRoomModel {
 			listKeys.start,
 			listKeys.changeTeam,
 			listKeys.leave,
+			listKeys.talk,
+			listKeys.invite,
 		}
 	}
-	wrm.list.Title = "User " + wrm.userGlobal.username
+	wrm.list.Title = "GameID: " + gameId.GameId
 	wrm.list.DisableQuitKeybindings()
 	wrm.list.SetFilteringEnabled(false)
 	wrm.list.SetShowStatusBar(false)
@@ -107,7 +108,8 @@ func newWaitingRoom(userGlobal userGlobal) waitingRoomModel {
 }
 
 func (m waitingRoomModel) Init() tea.Cmd {
-	return tea.Batch(m.every(wrUpdateInterval), m.userGlobal.LastWindowSizeReplay())
+	return tea.Batch(m.every(wrUpdateInterval), m.userGlobal.LastWindowSizeReplay(),
+		m.chat.join(m.gameId))
 }
 
 func (m waitingRoomModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -143,12 +145,30 @@ func (m waitingRoomModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.userGlobal.sizeMsg = msg
 		h, v := docStyle.GetFrameSize()
-		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.list.SetSize(msg.Width-h, msg.Height-v-wrChatHeight)
 		log.Debug("waitingRoomModel.Update: case tea.WindowSizeMsg:")
 
+	case chatPolledMsg, chatSentMsg:
+		m.chat, cmd = m.chat.Update(msg)
+		return m, cmd
+
 	case tea.KeyMsg:
+		if m.chat.focused() {
+			m.chat, cmd = m.chat.Update(msg)
+			return m, cmd
+		}
 
 		switch {
+		case key.Matches(msg, m.keys.talk):
+			return m, m.chat.focus()
+		case key.Matches(msg, m.keys.profile):
+			if p, ok := m.list.SelectedItem().(player); ok {
+				pm := newProfile(m, m.userGlobal, p.Name)
+				return pm, pm.Init()
+			}
+		case key.Matches(msg, m.keys.invite):
+			fm := newFriends(m, m.userGlobal, m.gameId)
+			return fm, fm.Init()
 		case key.Matches(msg, m.keys.quit):
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.ready):
@@ -183,7 +203,12 @@ func (m waitingRoomModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m waitingRoomModel) View() string {
-	return docStyle.Render(m.list.View())
+	h, _ := docStyle.GetFrameSize()
+	width := m.userGlobal.sizeMsg.Width - h
+	chat := chatPaneStyle.Width(width - 2).Render(
+		m.chat.View(width-2, wrChatHeight-2),
+	)
+	return docStyle.Render(lipgloss.JoinVertical(lipgloss.Left, m.list.View(), chat))
 }
 
 func (m *waitingRoomModel) every(interval time.Duration) tea.Cmd {
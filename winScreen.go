@@ -35,6 +35,20 @@ type winScreen struct {
 	winString  string
 	userGlobal userGlobal
 	debounced  bool
+
+	// Replays and the tutorial only show the scores.
+	replay bool
+
+	// Only for match games.
+	match      matchStatus
+	nextGameIn time.Duration
+
+	rematch         rematchStatus
+	rematchDeadline time.Time
+	keys            winScreenKeyMap
+
+	// Achievements the game unlocked, until the toasts expire.
+	toasts []achievement
 }
 
 type debounceMsg struct{}
@@ -106,13 +120,23 @@ func newWinScreen(gc *gameConfigPayload, players []playerModel, gameWon *gameWon
 			secondScoreCounter,
 			thirdScoreCounter,
 		},
-		scSize:     scSize,
-		winString:  winString,
-		userGlobal: userGlobal,
+		scSize:          scSize,
+		winString:       winString,
+		userGlobal:      userGlobal,
+		rematchDeadline: time.Now().Add(REMATCH_TIMEOUT),
+		keys:            newWinScreenKeyMap(userGlobal.keymap),
 	}
 }
 
 func (m winScreen) Init() tea.Cmd {
+	var poll tea.Cmd
+	switch {
+	case m.replay:
+	case m.gameConfig.Match != nil:
+		poll = m.pollMatch(0)
+	case m.canRematch():
+		poll = m.pollRematch(0)
+	}
 	if m.gameConfig.MaxPlayers == 3 {
 		return tea.Batch(
 			m.userGlobal.LastWindowSizeReplay(),
@@ -120,6 +144,7 @@ func (m winScreen) Init() tea.Cmd {
 			m.scArray[1].Init(),
 			m.scArray[2].Init(),
 			debounce(),
+			poll,
 		)
 	} else {
 		return tea.Batch(
@@ -127,6 +152,7 @@ func (m winScreen) Init() tea.Cmd {
 			m.scArray[0].Init(),
 			m.scArray[1].Init(),
 			debounce(),
+			poll,
 		)
 	}
 }
@@ -146,11 +172,69 @@ func (m winScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case debounceMsg:
 		m.debounced = true
 
+	case achievementsMsg:
+		m.toasts = msg
+		cmds = append(cmds, tea.Tick(ACHIEVEMENT_TOAST_TIME, func(time.Time) tea.Msg {
+			return achievementToastsExpiredMsg{}
+		}))
+
+	case achievementToastsExpiredMsg:
+		m.toasts = nil
+
+	case matchStatusMsg:
+		waiting := m.match.NextGameId == ""
+		m.match = matchStatus(msg)
+		switch {
+		case m.match.Over:
+		case m.match.NextGameId == "":
+			cmds = append(cmds, m.pollMatch(MATCH_POLL_INTERVAL))
+		case waiting:
+			m.nextGameIn = MATCH_NEXT_GAME
+			cmds = append(cmds, matchCountdown())
+		}
+
+	case rematchStatusMsg:
+		m.rematch = rematchStatus(msg)
+		if wrm, ok := m.rematchRoom(); ok {
+			return wrm, wrm.Init()
+		}
+		if !m.rematchOver() {
+			cmds = append(cmds, m.pollRematch(REMATCH_POLL_INTERVAL))
+		}
+
+	case rematchAckMsg:
+		m.rematch = rematchStatus(msg)
+		if wrm, ok := m.rematchRoom(); ok {
+			return wrm, wrm.Init()
+		}
+
+	case matchCountdownMsg:
+		m.nextGameIn -= time.Second
+		if m.nextGameIn <= 0 {
+			return m.nextMatchGame()
+		}
+		cmds = append(cmds, matchCountdown())
+
 	case tea.KeyMsg:
-		if m.debounced {
-			lm := newLobby(m.userGlobal)
-			return lm, lm.Init()
+		if !m.debounced {
+			break
+		}
+		if m.gameConfig.Match != nil && m.match.Over {
+			ms := newMatchSummary(m.match, *m.gameConfig.Match, m.userGlobal)
+			return ms, ms.Init()
+		}
+		if m.gameConfig.Match != nil && m.match.NextGameId != "" {
+			if msg.String() == "esc" {
+				return m.leaveMatch()
+			}
+			return m.nextMatchGame()
+		}
+		if m.canRematch() {
+			if cmd, ok := m.updateRematch(msg); ok {
+				return m, cmd
+			}
 		}
+		return m.leave()
 	case pretendCountMsg:
 		switch msg.id {
 		case 0:
@@ -193,10 +277,29 @@ func (m winScreen) View() string {
 		winner = " "
 	}
 
+	help := "Press any key to exit"
+	if m.canRematch() {
+		s = lipgloss.JoinVertical(lipgloss.Center, s, "", m.rematchView())
+		help = "Press any other key to exit"
+	}
+	if m.gameConfig.Match != nil && !m.replay {
+		s = lipgloss.JoinVertical(lipgloss.Center, s, "", m.matchView())
+		switch {
+		case m.match.Over:
+			help = "Press any key for the match summary"
+		case m.match.NextGameId != "":
+			help = "Press any key to play the next game"
+		}
+	}
+
+	if len(m.toasts) > 0 {
+		s = lipgloss.JoinVertical(lipgloss.Center, s, "", achievementToasts(m.toasts))
+	}
+
 	s = lipgloss.JoinVertical(lipgloss.Center,
 		winnerStyle.Render(winner),
 		s,
-		helpStyle.AlignHorizontal(lipgloss.Center).Render("Press any key to exit"))
+		helpStyle.AlignHorizontal(lipgloss.Center).Render(help))
 
 	return m.style.Render(s)
 }